@@ -0,0 +1,18 @@
+// Command nullmigrate migrates struct fields from the Null types of database/sql to null.T.
+//
+// Usage:
+//
+//	go install github.com/qawatake/null/analysis/cmd/nullmigrate@latest
+//	nullmigrate -fix ./...
+package main
+
+import (
+	"github.com/qawatake/null/analysis/nullmigrate"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(
+		nullmigrate.Analyzer,
+	)
+}
@@ -0,0 +1,20 @@
+// Command nullvet reports suspicious uses of null.T.
+//
+// It can be run directly or as a vet tool:
+//
+//	go install github.com/qawatake/null/analysis/cmd/nullvet@latest
+//	go vet -vettool=$(which nullvet) ./...
+package main
+
+import (
+	"github.com/qawatake/null/analysis/nullequal"
+	"github.com/qawatake/null/analysis/nullref"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(
+		nullequal.Analyzer,
+		nullref.Analyzer,
+	)
+}
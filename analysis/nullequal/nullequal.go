@@ -0,0 +1,191 @@
+// Package nullequal defines an Analyzer that reports comparisons of null.T[V] with ==
+// when V has an Equal method.
+//
+// # Analyzer nullequal
+//
+// nullequal: check for comparisons of null.T[V] with == when V has an Equal method
+//
+// If V has a method Equal(V) bool, such as time.Time, two values of null.T[V] may be
+// equal in the sense of null.T.Equal but different in the sense of ==.
+// For example, the same instant in different locations is a different time.Time.
+// The analyzer reports:
+//
+//   - x == y and x != y, suggesting x.Equal(y) and !x.Equal(y),
+//   - switch statements whose tag is null.T[V], suggesting a tagless switch calling Equal, and
+//   - map types whose key is null.T[V], for which no fix is suggested.
+package nullequal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check for comparisons of null.T[V] with == when V has an Equal method
+
+If V has a method Equal(V) bool, such as time.Time, values of null.T[V] should be
+compared with null.T.Equal. The nullequal analyzer reports == and != on null.T[V],
+switch statements on null.T[V] and maps keyed by null.T[V].`
+
+// Analyzer is the nullequal analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:     "nullequal",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/github.com/qawatake/null/analysis/nullequal",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const nullPath = "github.com/qawatake/null"
+
+func run(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	filter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.SwitchStmt)(nil),
+		(*ast.MapType)(nil),
+	}
+	in.Preorder(filter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			checkBinary(pass, n)
+		case *ast.SwitchStmt:
+			checkSwitch(pass, n)
+		case *ast.MapType:
+			if t := pass.TypesInfo.TypeOf(n.Key); hasEqual(t) {
+				pass.Reportf(n.Key.Pos(), "map keyed by %s compares keys with ==; the same key may be stored more than once", t)
+			}
+		}
+	})
+	return nil, nil
+}
+
+func checkBinary(pass *analysis.Pass, e *ast.BinaryExpr) {
+	if e.Op != token.EQL && e.Op != token.NEQ {
+		return
+	}
+	t := pass.TypesInfo.TypeOf(e.X)
+	if !hasEqual(t) {
+		return
+	}
+	not := ""
+	if e.Op == token.NEQ {
+		not = "!"
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     e.Pos(),
+		End:     e.End(),
+		Message: fmt.Sprintf("comparison of %s with %s; use %sEqual instead", t, e.Op, not),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Use Equal",
+			TextEdits: []analysis.TextEdit{
+				{Pos: e.X.Pos(), End: e.X.Pos(), NewText: []byte(not + open(e.X))},
+				{Pos: e.X.End(), End: e.Y.Pos(), NewText: []byte(closing(e.X) + ".Equal(")},
+				{Pos: e.Y.End(), End: e.Y.End(), NewText: []byte(")")},
+			},
+		}},
+	})
+}
+
+func checkSwitch(pass *analysis.Pass, s *ast.SwitchStmt) {
+	if s.Tag == nil {
+		return
+	}
+	t := pass.TypesInfo.TypeOf(s.Tag)
+	if !hasEqual(t) {
+		return
+	}
+	d := analysis.Diagnostic{
+		Pos:     s.Tag.Pos(),
+		End:     s.Tag.End(),
+		Message: fmt.Sprintf("switch on %s compares cases with ==; use a tagless switch calling Equal instead", t),
+	}
+	// The tag is evaluated once per case after the fix, so only fix side-effect-free tags.
+	if isPure(s.Tag) {
+		tag := open(s.Tag) + types.ExprString(s.Tag) + closing(s.Tag)
+		edits := []analysis.TextEdit{{Pos: s.Tag.Pos(), End: s.Tag.End()}}
+		for _, stmt := range s.Body.List {
+			clause := stmt.(*ast.CaseClause)
+			if len(clause.List) == 0 {
+				continue
+			}
+			conds := make([]string, len(clause.List))
+			for i, e := range clause.List {
+				conds[i] = fmt.Sprintf("%s.Equal(%s)", tag, types.ExprString(e))
+			}
+			edits = append(edits, analysis.TextEdit{
+				Pos:     clause.List[0].Pos(),
+				End:     clause.List[len(clause.List)-1].End(),
+				NewText: []byte(strings.Join(conds, " || ")),
+			})
+		}
+		d.SuggestedFixes = []analysis.SuggestedFix{{Message: "Use Equal", TextEdits: edits}}
+	}
+	pass.Report(d)
+}
+
+// hasEqual reports whether t is null.T[V] where V has a method Equal(V) bool.
+func hasEqual(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != nullPath || obj.Name() != "T" || named.TypeArgs().Len() != 1 {
+		return false
+	}
+	v := named.TypeArgs().At(0)
+	sel := types.NewMethodSet(v).Lookup(obj.Pkg(), "Equal")
+	if sel == nil {
+		// Equal is exported, so the package passed to Lookup does not matter.
+		return false
+	}
+	sig := sel.Type().(*types.Signature)
+	return sig.Params().Len() == 1 && types.Identical(sig.Params().At(0).Type(), v) &&
+		sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+// isPure reports whether evaluating e has no side effects.
+func isPure(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.ParenExpr:
+		return isPure(e.X)
+	case *ast.SelectorExpr:
+		return isPure(e.X)
+	case *ast.StarExpr:
+		return isPure(e.X)
+	}
+	return false
+}
+
+// open and closing return the parentheses needed for calling a method on e.
+func open(e ast.Expr) string {
+	if needsParens(e) {
+		return "("
+	}
+	return ""
+}
+
+func closing(e ast.Expr) string {
+	if needsParens(e) {
+		return ")"
+	}
+	return ""
+}
+
+func needsParens(e ast.Expr) bool {
+	switch e.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr, *ast.IndexListExpr, *ast.ParenExpr:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,12 @@
+package nullequal_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/null/analysis/nullequal"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), nullequal.Analyzer, "a")
+}
@@ -0,0 +1,61 @@
+package a
+
+import (
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+type Event struct {
+	At null.T[time.Time]
+}
+
+type Money int
+
+func (m Money) Equal(n Money) bool { return m == n }
+
+type BadEqual int
+
+func (b BadEqual) Equal(n int) bool { return int(b) == n }
+
+func next() null.T[time.Time] { return null.T[time.Time]{} }
+
+func f(x, y null.T[time.Time], e *Event, ch chan null.T[time.Time]) {
+	_ = x == y // want `comparison of github.com/qawatake/null.T\[time.Time\] with ==; use Equal instead`
+	_ = x != y // want `comparison of github.com/qawatake/null.T\[time.Time\] with !=; use !Equal instead`
+	_ = e.At == next() // want `comparison`
+	_ = <-ch == x      // want `comparison`
+	if (null.T[time.Time]{}) != x { // want `comparison`
+	}
+
+	switch e.At { // want `switch on github.com/qawatake/null.T\[time.Time\] compares cases with ==`
+	case x, y:
+	case null.T[time.Time]{}:
+	default:
+	}
+
+	switch next() { // want `switch on`
+	case x:
+	}
+
+	_ = map[null.T[time.Time]]int{} // want `map keyed by github.com/qawatake/null.T\[time.Time\] compares keys with ==`
+}
+
+func g(m, n null.T[Money], i, j null.T[int], b, c null.T[BadEqual]) {
+	_ = m == n // want `comparison`
+	_ = i == j
+	_ = b == c
+	switch i {
+	case j:
+	}
+	_ = map[null.T[int]]int{}
+	_ = x == y
+}
+
+func h(p *null.T[time.Time], x null.T[time.Time]) {
+	switch *p { // want `switch on`
+	case x:
+	}
+}
+
+var x, y int
@@ -0,0 +1,15 @@
+// Package null is a stub of github.com/qawatake/null for tests.
+package null
+
+type T[V comparable] struct {
+	v     V
+	valid bool
+}
+
+func From[V comparable](v V) T[V] {
+	return T[V]{v: v, valid: true}
+}
+
+func (t T[V]) Equal(u T[V]) bool {
+	return t == u
+}
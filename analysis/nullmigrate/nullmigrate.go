@@ -0,0 +1,703 @@
+// Package nullmigrate defines an Analyzer that migrates struct fields
+// from the Null types of database/sql to null.T.
+//
+// # Analyzer nullmigrate
+//
+// nullmigrate: migrate struct fields from database/sql Null types to null.T
+//
+// The analyzer reports struct fields of type sql.NullString, sql.NullInt64, ..., sql.NullTime and sql.Null[V],
+// and suggests fixes that
+//
+//   - rewrite the field type, e.g. sql.NullInt64 to null.T[int64],
+//   - rewrite composite literals stored in the field, e.g. sql.NullInt64{Int64: x, Valid: true} to null.From[int64](x),
+//   - rewrite x.F.Valid to !x.F.IsNull(), and
+//   - rewrite reads of the payload, e.g. x.F.Int64, to x.F.ValueOrZero().
+//
+// Only struct fields are migrated: local variables, parameters and results keep their types,
+// because changing them would break callers in other packages.
+// Writes such as x.F.Valid = true cannot be rewritten, because null.T is immutable,
+// so they are reported without a fix.
+// So are uses whose types the fixes cannot change, such as x.F passed to a parameter of type sql.NullInt64,
+// and values other than literals and migrated fields stored in x.F, such as the result of a call.
+// Embedded fields and fields whose payload is not comparable are left alone.
+// Exported fields are recorded as facts, so that their uses in importing packages are migrated as well.
+package nullmigrate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/edge"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `migrate struct fields from database/sql Null types to null.T
+
+The nullmigrate analyzer reports struct fields of type sql.NullString, sql.NullInt64,
+..., sql.NullTime and sql.Null[V], and suggests fixes that rewrite the field types,
+composite literals stored in the fields, x.F.Valid checks into !x.F.IsNull(),
+and payload reads such as x.F.Int64 into x.F.ValueOrZero().`
+
+// Analyzer is the nullmigrate analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:      "nullmigrate",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/qawatake/null/analysis/nullmigrate",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(migrated)},
+}
+
+// migrated is the fact that a struct field is migrated to null.T.
+type migrated struct{}
+
+func (*migrated) AFact()         {}
+func (*migrated) String() string { return "migrated" }
+
+const (
+	sqlPath  = "database/sql"
+	nullPath = "github.com/qawatake/null"
+)
+
+func run(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Fields may be used in files other than the one declaring them,
+	// so collect all the fields to migrate first.
+	fields := make(map[*types.Var]bool)
+	for cur := range in.Root().Preorder((*ast.StructType)(nil)) {
+		for _, f := range cur.Node().(*ast.StructType).Fields.List {
+			payload, ok := sqlNullPayload(pass.TypesInfo.TypeOf(f.Type))
+			if !ok || len(f.Names) == 0 {
+				continue
+			}
+			if !types.Comparable(payload.Type()) {
+				pass.Reportf(f.Type.Pos(), "cannot migrate to null.T: %s is not comparable", payload.Type())
+				continue
+			}
+			for _, name := range f.Names {
+				if v, ok := pass.TypesInfo.Defs[name].(*types.Var); ok {
+					fields[v] = true
+					if v.Exported() {
+						pass.ExportObjectFact(v, new(migrated))
+					}
+				}
+			}
+		}
+	}
+
+	for fileCur := range in.Root().Children() {
+		m := &migrator{
+			pass:    pass,
+			file:    fileCur.Node().(*ast.File),
+			fields:  fields,
+			imports: make(map[string]string),
+		}
+		m.run(fileCur)
+	}
+	return nil, nil
+}
+
+// migrator collects the fixes for a single file.
+type migrator struct {
+	pass *analysis.Pass
+	file *ast.File
+	// fields holds the fields to migrate declared in the package.
+	fields map[*types.Var]bool
+	// imports maps the paths imported by the file to their names.
+	imports map[string]string
+	// missing holds the imports that fixes refer to but the file does not have.
+	missing []importSpec
+	// replaced holds the composite literals replaced as a whole.
+	replaced []ast.Node
+	// sqlUses is the number of references to database/sql in the file,
+	// and sqlRemoved is the number of those removed by the fixes.
+	sqlUses, sqlRemoved int
+	diags               []analysis.Diagnostic
+}
+
+func (m *migrator) run(fileCur inspector.Cursor) {
+	for _, spec := range m.file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if pkgName := m.pass.TypesInfo.PkgNameOf(spec); pkgName != nil {
+			m.imports[path] = pkgName.Name()
+		}
+	}
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && m.isSQLPkg(id) {
+			m.sqlUses++
+		}
+		return true
+	})
+
+	for cur := range fileCur.Preorder((*ast.StructType)(nil)) {
+		for _, f := range cur.Node().(*ast.StructType).Fields.List {
+			if len(f.Names) > 0 && m.fields[m.pass.TypesInfo.Defs[f.Names[0]].(*types.Var)] {
+				m.migrateFieldType(f)
+			}
+		}
+	}
+	for cur := range fileCur.Preorder((*ast.CompositeLit)(nil)) {
+		if m.storedInField(cur) {
+			m.migrateLiteral(cur.Node().(*ast.CompositeLit))
+		}
+	}
+	for cur := range fileCur.Preorder((*ast.SelectorExpr)(nil)) {
+		m.migrateSelector(cur)
+		m.checkUse(cur)
+	}
+	for cur := range fileCur.Preorder((*ast.AssignStmt)(nil), (*ast.CompositeLit)(nil)) {
+		m.checkStores(cur.Node())
+	}
+
+	importEdits := m.importEdits()
+	for _, d := range m.diags {
+		for i := range d.SuggestedFixes {
+			d.SuggestedFixes[i].TextEdits = append(d.SuggestedFixes[i].TextEdits, importEdits...)
+		}
+		m.pass.Report(d)
+	}
+}
+
+// migrateFieldType rewrites the type of f to null.T.
+func (m *migrator) migrateFieldType(f *ast.Field) {
+	payload, _ := sqlNullPayload(m.pass.TypesInfo.TypeOf(f.Type))
+	to := fmt.Sprintf("%s.T[%s]", m.qualifier(nullPath, "null"), m.typeString(payload.Type()))
+	m.removeSQL(f.Type)
+	m.report(f.Type, fmt.Sprintf("use %s instead of %s", to, types.ExprString(f.Type)), analysis.TextEdit{
+		Pos:     f.Type.Pos(),
+		End:     f.Type.End(),
+		NewText: []byte(to),
+	})
+}
+
+// storedInField reports whether the composite literal at cur is a database/sql Null value
+// stored in a migrated field, either in a struct literal or by an assignment.
+func (m *migrator) storedInField(cur inspector.Cursor) bool {
+	lit := cur.Node().(*ast.CompositeLit)
+	if lit.Type == nil {
+		return false
+	}
+	if _, ok := sqlNullPayload(m.pass.TypesInfo.TypeOf(lit)); !ok {
+		return false
+	}
+	switch k, idx := cur.ParentEdge(); k {
+	case edge.KeyValueExpr_Value:
+		kv := cur.Parent().Node().(*ast.KeyValueExpr)
+		if key, ok := kv.Key.(*ast.Ident); ok {
+			v, ok := m.pass.TypesInfo.Uses[key].(*types.Var)
+			return ok && m.isMigrated(v)
+		}
+	case edge.AssignStmt_Rhs:
+		assign := cur.Parent().Node().(*ast.AssignStmt)
+		if len(assign.Lhs) == len(assign.Rhs) {
+			return m.isField(assign.Lhs[idx])
+		}
+	case edge.CompositeLit_Elts:
+		if st, ok := m.pass.TypesInfo.TypeOf(cur.Parent().Node().(*ast.CompositeLit)).Underlying().(*types.Struct); ok {
+			return m.isMigrated(st.Field(idx))
+		}
+	}
+	return false
+}
+
+// migrateLiteral rewrites lit to null.From or a null T.
+func (m *migrator) migrateLiteral(lit *ast.CompositeLit) {
+	payload, _ := sqlNullPayload(m.pass.TypesInfo.TypeOf(lit))
+	var value, valid ast.Expr
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if kv.Key.(*ast.Ident).Name == "Valid" {
+				valid = kv.Value
+			} else {
+				value = kv.Value
+			}
+		} else if i == 0 {
+			value = elt
+		} else {
+			valid = elt
+		}
+	}
+
+	null := m.qualifier(nullPath, "null")
+	typ := m.typeString(payload.Type())
+	isValid := false
+	if valid != nil {
+		tv := m.pass.TypesInfo.Types[valid]
+		if tv.Value == nil || tv.Value.Kind() != constant.Bool {
+			m.report(lit, fmt.Sprintf("cannot migrate %s literal with non-constant Valid; use %s.From or %s.T{} instead", types.ExprString(lit.Type), null, null))
+			return
+		}
+		isValid = constant.BoolVal(tv.Value)
+	}
+
+	m.removeSQL(lit.Type)
+	if !isValid || value == nil {
+		to := fmt.Sprintf("%s.T[%s]{}", null, typ)
+		if isValid {
+			to = fmt.Sprintf("%s.From[%s](%s)", null, typ, zero(payload.Type(), typ))
+		}
+		m.replaced = append(m.replaced, lit)
+		m.report(lit, fmt.Sprintf("use %s instead of %s literal", to, types.ExprString(lit.Type)), analysis.TextEdit{
+			Pos:     lit.Pos(),
+			End:     lit.End(),
+			NewText: []byte(to),
+		})
+		return
+	}
+	m.report(lit, fmt.Sprintf("use %s.From instead of %s literal", null, types.ExprString(lit.Type)),
+		analysis.TextEdit{
+			Pos:     lit.Pos(),
+			End:     value.Pos(),
+			NewText: []byte(fmt.Sprintf("%s.From[%s](", null, typ)),
+		},
+		analysis.TextEdit{
+			Pos:     value.End(),
+			End:     lit.End(),
+			NewText: []byte(")"),
+		},
+	)
+}
+
+// migrateSelector rewrites x.F.Valid and x.F.<payload> where x.F is a migrated field.
+func (m *migrator) migrateSelector(cur inspector.Cursor) {
+	sel := cur.Node().(*ast.SelectorExpr)
+	if !m.isField(sel.X) || m.isReplaced(sel) {
+		return
+	}
+	payload, _ := sqlNullPayload(m.pass.TypesInfo.TypeOf(sel.X))
+	name := sel.Sel.Name
+	if name != "Valid" && name != payload.Name() {
+		return
+	}
+
+	switch k, _ := cur.ParentEdge(); {
+	case k == edge.AssignStmt_Lhs,
+		k == edge.IncDecStmt_X,
+		k == edge.UnaryExpr_X && cur.Parent().Node().(*ast.UnaryExpr).Op == token.AND:
+		m.report(sel, fmt.Sprintf("cannot migrate write to %s: null.T is immutable; assign %s.From or %s.T{} instead",
+			types.ExprString(sel), m.qualifier(nullPath, "null"), m.qualifier(nullPath, "null")))
+		return
+	}
+
+	suffix := analysis.TextEdit{Pos: sel.X.End(), End: sel.End()}
+	if name != "Valid" {
+		suffix.NewText = []byte(".ValueOrZero()")
+		m.report(sel, fmt.Sprintf("use ValueOrZero instead of %s", name), suffix)
+		return
+	}
+	suffix.NewText = []byte(".IsNull()")
+	if k, _ := cur.ParentEdge(); k == edge.UnaryExpr_X && cur.Parent().Node().(*ast.UnaryExpr).Op == token.NOT {
+		not := cur.Parent().Node()
+		m.report(not, "use IsNull instead of Valid", analysis.TextEdit{Pos: not.Pos(), End: sel.Pos()}, suffix)
+		return
+	}
+	m.report(sel, "use IsNull instead of Valid", analysis.TextEdit{Pos: sel.Pos(), End: sel.Pos(), NewText: []byte("!")}, suffix)
+}
+
+// checkUse reports the migrated field selected at cur
+// if it is used where a database/sql Null type is required, such as an argument of type sql.NullInt64.
+func (m *migrator) checkUse(cur inspector.Cursor) {
+	sel := cur.Node().(*ast.SelectorExpr)
+	if !m.isField(sel) || m.isReplaced(sel) {
+		return
+	}
+	// &x.F is used where a pointer to the Null type is required.
+	for {
+		k, _ := cur.ParentEdge()
+		if k != edge.ParenExpr_X && (k != edge.UnaryExpr_X || cur.Parent().Node().(*ast.UnaryExpr).Op != token.AND) {
+			break
+		}
+		cur = cur.Parent()
+	}
+	want := m.requiredType(cur)
+	if want == nil {
+		return
+	}
+	if ptr, ok := want.(*types.Pointer); ok {
+		want = ptr.Elem()
+	}
+	if _, ok := sqlNullPayload(want); ok {
+		m.report(sel, fmt.Sprintf("cannot migrate use of %s as %s; convert it by hand",
+			types.ExprString(sel), types.TypeString(want, (*types.Package).Name)))
+	}
+}
+
+// requiredType returns the type that the expression at cur is assigned to, or nil if it is unknown
+// or is that of a migrated field.
+func (m *migrator) requiredType(cur inspector.Cursor) types.Type {
+	info := m.pass.TypesInfo
+	switch k, idx := cur.ParentEdge(); k {
+	case edge.CallExpr_Args:
+		call := cur.Parent().Node().(*ast.CallExpr)
+		if tv := info.Types[call.Fun]; tv.IsType() {
+			return tv.Type
+		}
+		sig, ok := info.TypeOf(call.Fun).Underlying().(*types.Signature)
+		if !ok {
+			return nil
+		}
+		params := sig.Params()
+		if last := params.Len() - 1; sig.Variadic() && idx >= last {
+			if call.Ellipsis.IsValid() {
+				return params.At(last).Type()
+			}
+			if s, ok := params.At(last).Type().Underlying().(*types.Slice); ok {
+				return s.Elem()
+			}
+			return nil
+		}
+		if idx < params.Len() {
+			return params.At(idx).Type()
+		}
+	case edge.ReturnStmt_Results:
+		for fn := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+			var sig *types.Signature
+			switch fn := fn.Node().(type) {
+			case *ast.FuncDecl:
+				sig = info.Defs[fn.Name].Type().(*types.Signature)
+			case *ast.FuncLit:
+				sig = info.TypeOf(fn).(*types.Signature)
+			}
+			if len(cur.Parent().Node().(*ast.ReturnStmt).Results) == sig.Results().Len() {
+				return sig.Results().At(idx).Type()
+			}
+			return nil
+		}
+	case edge.AssignStmt_Rhs:
+		assign := cur.Parent().Node().(*ast.AssignStmt)
+		if len(assign.Lhs) == len(assign.Rhs) && !m.isField(assign.Lhs[idx]) {
+			return info.TypeOf(assign.Lhs[idx])
+		}
+	case edge.ValueSpec_Values:
+		spec := cur.Parent().Node().(*ast.ValueSpec)
+		if len(spec.Names) == len(spec.Values) {
+			return info.TypeOf(spec.Names[idx])
+		}
+	case edge.KeyValueExpr_Value:
+		kv := cur.Parent().Node().(*ast.KeyValueExpr)
+		if key, ok := kv.Key.(*ast.Ident); ok {
+			if v, ok := info.Uses[key].(*types.Var); ok && v.IsField() {
+				if m.isMigrated(v) {
+					return nil
+				}
+				return v.Type()
+			}
+		}
+		return elemType(info.TypeOf(cur.Parent().Parent().Node().(*ast.CompositeLit)))
+	case edge.CompositeLit_Elts:
+		t := info.TypeOf(cur.Parent().Node().(*ast.CompositeLit))
+		if st, ok := t.Underlying().(*types.Struct); ok {
+			if m.isMigrated(st.Field(idx)) {
+				return nil
+			}
+			return st.Field(idx).Type()
+		}
+		return elemType(t)
+	case edge.SendStmt_Value:
+		return elemType(info.TypeOf(cur.Parent().Node().(*ast.SendStmt).Chan))
+	}
+	return nil
+}
+
+// checkStores reports the values stored in migrated fields by n that the fixes cannot rewrite,
+// that is, values other than database/sql Null literals and migrated fields.
+func (m *migrator) checkStores(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		for i, lhs := range n.Lhs {
+			if !m.isField(lhs) {
+				continue
+			}
+			if len(n.Lhs) != len(n.Rhs) {
+				m.reportStore(types.ExprString(lhs), n.Rhs[0])
+				continue
+			}
+			m.checkStore(types.ExprString(lhs), n.Rhs[i])
+		}
+	case *ast.CompositeLit:
+		st, ok := m.pass.TypesInfo.TypeOf(n).Underlying().(*types.Struct)
+		if !ok {
+			return
+		}
+		for i, elt := range n.Elts {
+			field, value := st.Field(i), elt
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				field, _ = m.pass.TypesInfo.Uses[kv.Key.(*ast.Ident)].(*types.Var)
+				value = kv.Value
+			}
+			if field != nil && m.isMigrated(field) {
+				m.checkStore(field.Name(), value)
+			}
+		}
+	}
+}
+
+func (m *migrator) checkStore(field string, value ast.Expr) {
+	if m.isField(value) {
+		return
+	}
+	if lit, ok := value.(*ast.CompositeLit); ok && lit.Type != nil {
+		if _, ok := sqlNullPayload(m.pass.TypesInfo.TypeOf(lit)); ok {
+			return
+		}
+	}
+	m.reportStore(field, value)
+}
+
+func (m *migrator) reportStore(field string, value ast.Expr) {
+	m.report(value, fmt.Sprintf("cannot migrate %s stored in %s; convert it to null.T by hand", types.ExprString(value), field))
+}
+
+func (m *migrator) report(n ast.Node, message string, edits ...analysis.TextEdit) {
+	d := analysis.Diagnostic{Pos: n.Pos(), End: n.End(), Message: message}
+	if len(edits) > 0 {
+		d.SuggestedFixes = []analysis.SuggestedFix{{Message: "Migrate to null.T", TextEdits: edits}}
+	}
+	m.diags = append(m.diags, d)
+}
+
+// importEdits returns the edits adding the imports the fixes need
+// and removing database/sql if no reference to it remains.
+func (m *migrator) importEdits() []analysis.TextEdit {
+	var edits []analysis.TextEdit
+	missing := m.missing
+	if m.sqlUses == m.sqlRemoved {
+		tok := m.pass.Fset.File(m.file.Pos())
+		for _, decl := range m.file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.IMPORT {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ImportSpec)
+				if path, _ := strconv.Unquote(spec.Path.Value); path != sqlPath {
+					continue
+				}
+				switch line := tok.Line(spec.Pos()); {
+				case decl.Lparen.IsValid():
+					// Remove the whole line, which is followed by at least the closing parenthesis.
+					edits = append(edits, analysis.TextEdit{Pos: tok.LineStart(line), End: tok.LineStart(line + 1)})
+				case len(missing) > 0:
+					// Reuse the import declaration of database/sql.
+					edits = append(edits, analysis.TextEdit{Pos: spec.Pos(), End: spec.End(), NewText: []byte(missing[0].String())})
+					missing = missing[1:]
+				default:
+					edits = append(edits, analysis.TextEdit{Pos: decl.Pos(), End: decl.End()})
+				}
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return edits
+	}
+	for _, decl := range m.file.Decls {
+		if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.IMPORT && decl.Lparen.IsValid() {
+			// Add standard packages to the first group and others to a new group.
+			var std, other string
+			for _, spec := range missing {
+				if strings.Contains(spec.path, ".") {
+					other += "\t" + spec.String() + "\n"
+				} else {
+					std += "\n\t" + spec.String()
+				}
+			}
+			if std != "" {
+				edits = append(edits, analysis.TextEdit{Pos: decl.Lparen + 1, End: decl.Lparen + 1, NewText: []byte(std)})
+			}
+			if other != "" {
+				edits = append(edits, analysis.TextEdit{Pos: decl.Rparen, End: decl.Rparen, NewText: []byte("\n" + other)})
+			}
+			return edits
+		}
+	}
+	var text string
+	for _, spec := range missing {
+		text += "\nimport " + spec.String()
+	}
+	return append(edits, analysis.TextEdit{Pos: m.file.Name.End(), End: m.file.Name.End(), NewText: []byte("\n" + text)})
+}
+
+// qualifier returns the name by which the file refers to the package at path, whose name is name,
+// recording the import as missing if necessary.
+// If name is already declared, such as by gopkg.in/guregu/null.v4 imported as null,
+// the import is renamed, e.g. to qnull.
+func (m *migrator) qualifier(path, name string) string {
+	if name, ok := m.imports[path]; ok {
+		return name
+	}
+	base := name
+	for i := 1; m.declared(name); i++ {
+		name = "q" + base
+		if i > 1 {
+			name += strconv.Itoa(i)
+		}
+	}
+	m.imports[path] = name
+	m.missing = append(m.missing, importSpec{name: name, path: path})
+	return name
+}
+
+// declared reports whether name is declared in the file, in any scope, or in the package,
+// or is the name of an import added by the fixes.
+func (m *migrator) declared(name string) bool {
+	for _, imported := range m.imports {
+		if imported == name {
+			return true
+		}
+	}
+	scope := m.pass.TypesInfo.Scopes[m.file]
+	if _, obj := scope.LookupParent(name, token.NoPos); obj != nil {
+		return true
+	}
+	var inner func(s *types.Scope) bool
+	inner = func(s *types.Scope) bool {
+		for child := range s.Children() {
+			if child.Lookup(name) != nil || inner(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return inner(scope)
+}
+
+func (m *migrator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == m.pass.Pkg {
+			return ""
+		}
+		return m.qualifier(p.Path(), p.Name())
+	})
+}
+
+// importSpec is an import added by the fixes.
+type importSpec struct {
+	name, path string
+}
+
+// String returns the import spec, which names the package only if the name differs from the last element of the path.
+func (s importSpec) String() string {
+	if s.name == lastElem(s.path) {
+		return strconv.Quote(s.path)
+	}
+	return s.name + " " + strconv.Quote(s.path)
+}
+
+// removeSQL records the references to database/sql in n, which a fix removes.
+func (m *migrator) removeSQL(n ast.Node) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && m.isSQLPkg(id) {
+			m.sqlRemoved++
+		}
+		return true
+	})
+}
+
+func (m *migrator) isSQLPkg(id *ast.Ident) bool {
+	pkgName, ok := m.pass.TypesInfo.Uses[id].(*types.PkgName)
+	return ok && pkgName.Imported().Path() == sqlPath
+}
+
+// isField reports whether e selects a migrated field.
+func (m *migrator) isField(e ast.Expr) bool {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	s, ok := m.pass.TypesInfo.Selections[sel]
+	if !ok || s.Kind() != types.FieldVal {
+		return false
+	}
+	v, ok := s.Obj().(*types.Var)
+	return ok && m.isMigrated(v)
+}
+
+// isMigrated reports whether v is a field to migrate,
+// declared either in the package or in an imported package.
+func (m *migrator) isMigrated(v *types.Var) bool {
+	if v.Pkg() == m.pass.Pkg {
+		return m.fields[v]
+	}
+	return m.pass.ImportObjectFact(v, new(migrated))
+}
+
+func (m *migrator) isReplaced(n ast.Node) bool {
+	for _, r := range m.replaced {
+		if r.Pos() <= n.Pos() && n.End() <= r.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// sqlNullPayload returns the payload field of t if t is one of the Null types of database/sql,
+// all of which are structs of a payload field followed by Valid.
+func sqlNullPayload(t types.Type) (*types.Var, bool) {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != sqlPath || len(obj.Name()) < 4 || obj.Name()[:4] != "Null" {
+		return nil, false
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || st.NumFields() != 2 || st.Field(1).Name() != "Valid" {
+		return nil, false
+	}
+	return st.Field(0), true
+}
+
+// elemType returns the element type of t if t is a slice, array, map or channel type, or nil otherwise.
+func elemType(t types.Type) types.Type {
+	if t == nil {
+		return nil
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem()
+	case *types.Array:
+		return u.Elem()
+	case *types.Map:
+		return u.Elem()
+	case *types.Chan:
+		return u.Elem()
+	}
+	return nil
+}
+
+// zero returns an expression for the zero value of t, whose type expression is typ.
+// The expression may be untyped.
+func zero(t types.Type, typ string) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		default:
+			return "0"
+		}
+	case *types.Pointer, *types.Interface, *types.Chan:
+		return "nil"
+	}
+	return typ + "{}"
+}
+
+func lastElem(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '/' {
+			return path[i+1:]
+		}
+	}
+	return path
+}
@@ -0,0 +1,12 @@
+package nullmigrate_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/null/analysis/nullmigrate"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), nullmigrate.Analyzer, "a", "b", "c", "d", "e", "f")
+}
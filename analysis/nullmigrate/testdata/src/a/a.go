@@ -0,0 +1,64 @@
+package a
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type User struct {
+	ID       int64
+	Name     sql.NullString  // want Name:"migrated" `use null.T\[string\] instead of sql.NullString`
+	Age      sql.NullInt64   // want Age:"migrated" `use null.T\[int64\] instead of sql.NullInt64`
+	Score    sql.NullFloat64 // want Score:"migrated" `use null.T\[float64\] instead of sql.NullFloat64`
+	Nickname sql.Null[string] // want Nickname:"migrated" `use null.T\[string\] instead of sql.Null\[string\]`
+	Tags     sql.Null[[]string] // want `cannot migrate to null.T: \[\]string is not comparable`
+}
+
+func NewUser(name string, age int64) User {
+	return User{
+		Name:     sql.NullString{String: name, Valid: true}, // want `use null.From instead of sql.NullString literal`
+		Age:      sql.NullInt64{Int64: age, Valid: age > 0}, // want `cannot migrate sql.NullInt64 literal with non-constant Valid`
+		Score:    sql.NullFloat64{},                         // want `use null.T\[float64\]{} instead of sql.NullFloat64 literal`
+		Nickname: sql.Null[string]{Valid: true},             // want `use null.From\[string\]\(""\) instead of sql.Null\[string\] literal`
+	}
+}
+
+func (u *User) Describe() string {
+	if !u.Name.Valid { // want `use IsNull instead of Valid`
+		return "anonymous"
+	}
+	if u.Age.Valid && u.Age.Int64 >= 20 { // want `use IsNull instead of Valid` `use ValueOrZero instead of Int64`
+		return fmt.Sprintf("%s (adult)", u.Name.String) // want `use ValueOrZero instead of String`
+	}
+	return u.Name.String + u.Nickname.V // want `use ValueOrZero instead of String` `use ValueOrZero instead of V`
+}
+
+func (u *User) Reset() {
+	u.Score = sql.NullFloat64{Float64: 0, Valid: false} // want `use null.T\[float64\]{} instead of sql.NullFloat64 literal`
+	u.Age.Valid = false                                  // want `cannot migrate write to u.Age.Valid: null.T is immutable`
+	u.Age.Int64++                                        // want `cannot migrate write to u.Age.Int64: null.T is immutable`
+}
+
+// local variables are not migrated.
+func local() bool {
+	var n sql.NullInt64
+	return n.Valid
+}
+
+func take(n sql.NullInt64) int64 { return n.Int64 }
+
+func get() sql.NullInt64 { return sql.NullInt64{Int64: 1, Valid: true} }
+
+// Uses whose types the fixes cannot change are reported without a fix.
+func (u *User) Sync(rows *sql.Rows) (sql.NullInt64, error) {
+	take(u.Age)   // want `cannot migrate use of u.Age as sql.NullInt64; convert it by hand`
+	u.Age = get() // want `cannot migrate get\(\) stored in u.Age; convert it to null.T by hand`
+	n := u.Age    // want `cannot migrate use of u.Age as sql.NullInt64`
+	take(n)
+	*u = User{Age: get()} // want `cannot migrate get\(\) stored in Age`
+	fmt.Println(u.Age)
+	if err := rows.Scan(&u.Age); err != nil {
+		return sql.NullInt64{}, err
+	}
+	return u.Age, nil // want `cannot migrate use of u.Age as sql.NullInt64`
+}
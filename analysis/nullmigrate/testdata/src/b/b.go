@@ -0,0 +1,25 @@
+package b
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Event struct {
+	At    sql.NullTime // want At:"migrated" `use null.T\[time.Time\] instead of sql.NullTime`
+	Count sql.NullInt32 // want Count:"migrated" `use null.T\[int32\] instead of sql.NullInt32`
+}
+
+func New(at time.Time) *Event {
+	e := &Event{}
+	e.At = sql.NullTime{Time: at, Valid: true} // want `use null.From instead of sql.NullTime literal`
+	e.Count = sql.NullInt32{5, true}            // want `use null.From instead of sql.NullInt32 literal`
+	return e
+}
+
+func (e *Event) Elapsed(now time.Time) time.Duration {
+	if e.At.Valid { // want `use IsNull instead of Valid`
+		return now.Sub(e.At.Time) // want `use ValueOrZero instead of Time`
+	}
+	return 0
+}
@@ -0,0 +1,16 @@
+package c
+
+import (
+	"database/sql"
+
+	"github.com/qawatake/null"
+)
+
+type Order struct {
+	Paid     sql.NullBool // want Paid:"migrated" `use null.T\[bool\] instead of sql.NullBool`
+	Discount null.T[int]
+}
+
+func Unpaid() sql.NullBool {
+	return sql.NullBool{Bool: false, Valid: true}
+}
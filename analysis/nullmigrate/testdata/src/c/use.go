@@ -0,0 +1,6 @@
+package c
+
+// Fields declared in another file are migrated as well.
+func IsPaid(o Order) bool {
+	return o.Paid.Valid && o.Paid.Bool // want `use IsNull instead of Valid` `use ValueOrZero instead of Bool`
+}
@@ -0,0 +1,7 @@
+package d
+
+import "database/sql"
+
+type Account struct {
+	Balance sql.NullInt64 // want Balance:"migrated" `use null.T\[int64\] instead of sql.NullInt64`
+}
@@ -0,0 +1,19 @@
+package e
+
+import (
+	"database/sql"
+
+	"d"
+)
+
+// Fields declared in an imported package are migrated as well.
+func Open(balance int64) d.Account {
+	return d.Account{Balance: sql.NullInt64{Int64: balance, Valid: true}} // want `use null.From instead of sql.NullInt64 literal`
+}
+
+func Balance(a d.Account) int64 {
+	if !a.Balance.Valid { // want `use IsNull instead of Valid`
+		return 0
+	}
+	return a.Balance.Int64 // want `use ValueOrZero instead of Int64`
+}
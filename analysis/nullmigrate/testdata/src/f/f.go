@@ -0,0 +1,22 @@
+package f
+
+import (
+	"database/sql"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+// The import of github.com/qawatake/null is renamed,
+// since guregu/null is imported as null.
+type Profile struct {
+	Name null.String
+	Age  sql.NullInt64      // want Age:"migrated" `use qnull.T\[int64\] instead of sql.NullInt64`
+	Bio  sql.Null[null.String] // want Bio:"migrated" `use qnull.T\[null.String\] instead of sql.Null\[null.String\]`
+}
+
+func New(name string) Profile {
+	return Profile{
+		Name: null.StringFrom(name),
+		Age:  sql.NullInt64{}, // want `use qnull.T\[int64\]{} instead of sql.NullInt64 literal`
+	}
+}
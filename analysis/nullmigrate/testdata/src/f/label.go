@@ -0,0 +1,15 @@
+package f
+
+import "database/sql"
+
+// The import is renamed if a local declaration would shadow it.
+type Label struct {
+	Text sql.NullString // want Text:"migrated" `use qnull.T\[string\] instead of sql.NullString`
+}
+
+func (l Label) Or(null string) string {
+	if l.Text.Valid { // want `use IsNull instead of Valid`
+		return l.Text.String // want `use ValueOrZero instead of String`
+	}
+	return null
+}
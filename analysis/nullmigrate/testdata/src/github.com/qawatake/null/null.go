@@ -0,0 +1,19 @@
+// Package null is a stub of github.com/qawatake/null for tests.
+package null
+
+type T[V comparable] struct {
+	v     V
+	valid bool
+}
+
+func From[V comparable](v V) T[V] {
+	return T[V]{v: v, valid: true}
+}
+
+func (t T[V]) IsNull() bool {
+	return !t.valid
+}
+
+func (t T[V]) ValueOrZero() V {
+	return t.v
+}
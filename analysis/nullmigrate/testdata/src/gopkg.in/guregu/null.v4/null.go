@@ -0,0 +1,12 @@
+// Package null is a stub of gopkg.in/guregu/null.v4 for tests.
+package null
+
+import "database/sql"
+
+type String struct {
+	sql.NullString
+}
+
+func StringFrom(s string) String {
+	return String{sql.NullString{String: s, Valid: true}}
+}
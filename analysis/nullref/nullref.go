@@ -0,0 +1,209 @@
+// Package nullref defines an Analyzer that reports instantiations of null.T[V]
+// whose payload V carries references.
+//
+// # Analyzer nullref
+//
+// nullref: check for null.T[V] whose payload carries references
+//
+// null.T is immutable only if its payload is. If V is or contains a pointer, channel,
+// interface or unsafe.Pointer, copies of a null.T[V] share the referenced data,
+// and modifying it through one copy is visible through the others.
+// Maps, slices and functions are not comparable, so they can only be reached through these.
+//
+// The analyzer walks the structure of V for every instantiation of the generic types and functions
+// of package null that hold a payload: null.T, null.Sensitive, null.Atomic, null.From and null.FromPtr.
+// Others, such as null.Coalesce, whose type argument is not a payload, are not checked.
+// Unexported fields are walked as well, because methods of their package may modify the referenced data.
+// time.Time is accepted: its only reference is the *time.Location, which is not modified once created.
+// Type parameters are not reported.
+//
+// Deliberate uses are allowed by a //nullref:allow comment on the same line as
+// the instantiation or on a line of its own above it.
+// A //nullref:allow comment in the doc comment of a type declaration allows the type everywhere.
+package nullref
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const doc = `check for null.T[V] whose payload carries references
+
+If V is or contains a pointer, channel, interface or unsafe.Pointer,
+copies of null.T[V] share the referenced data. Deliberate uses are allowed
+by a //nullref:allow comment on the line of the instantiation or on a line of its own above it,
+or in the doc comment of the payload type.`
+
+// Analyzer is the nullref analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:      "nullref",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/qawatake/null/analysis/nullref",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(allowed)},
+}
+
+const (
+	nullPath  = "github.com/qawatake/null"
+	directive = "//nullref:allow"
+)
+
+// payloadHolders holds the names of the generic types and functions of package null
+// whose type argument is the payload.
+var payloadHolders = map[string]bool{
+	"T":         true,
+	"Sensitive": true,
+	"Atomic":    true,
+	"From":      true,
+	"FromPtr":   true,
+}
+
+// allowed is a fact attached to type names annotated with //nullref:allow.
+type allowed struct{}
+
+func (*allowed) AFact() {}
+
+func (*allowed) String() string { return "allowed" }
+
+func run(pass *analysis.Pass) (any, error) {
+	// lines holds, per file, the lines allowed by a directive.
+	lines := make(map[*token.File]map[int]bool)
+	for _, f := range pass.Files {
+		tf := pass.Fset.File(f.Pos())
+		src, err := pass.ReadFile(tf.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range f.Comments {
+			for _, c := range g.List {
+				if !isDirective(c.Text) {
+					continue
+				}
+				if lines[tf] == nil {
+					lines[tf] = make(map[int]bool)
+				}
+				line := tf.Line(c.Pos())
+				// A directive on a line of its own allows the next line.
+				if start := tf.LineStart(line); len(bytes.TrimSpace(src[tf.Offset(start):tf.Offset(c.Pos())])) == 0 {
+					line++
+				}
+				lines[tf][line] = true
+			}
+		}
+		exportAllowed(pass, f)
+	}
+
+	for id, inst := range pass.TypesInfo.Instances {
+		obj := pass.TypesInfo.Uses[id]
+		if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != nullPath || !payloadHolders[obj.Name()] {
+			continue
+		}
+		tf := pass.Fset.File(id.Pos())
+		if lines[tf][tf.Line(id.Pos())] {
+			continue
+		}
+		v := inst.TypeArgs.At(0)
+		if path, ok := (&walker{pass: pass, seen: make(map[types.Type]bool)}).ref(v); ok {
+			pass.Reportf(id.Pos(), "payload %s of null.T carries a reference (%s); copies of null.T share it", typeString(pass, v), path)
+		}
+	}
+	return nil, nil
+}
+
+// exportAllowed exports the allowed fact for the type declarations in f annotated with the directive.
+func exportAllowed(pass *analysis.Pass, f *ast.File) {
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+			doc := spec.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			if doc == nil || !hasDirective(doc) {
+				continue
+			}
+			if obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName); ok {
+				pass.ExportObjectFact(obj, new(allowed))
+			}
+		}
+	}
+}
+
+type walker struct {
+	pass *analysis.Pass
+	seen map[types.Type]bool
+}
+
+// ref reports whether t carries a reference, and if so, where.
+func (w *walker) ref(t types.Type) (string, bool) {
+	switch t := t.(type) {
+	case *types.TypeParam:
+		return "", false
+	case *types.Alias:
+		return w.ref(types.Unalias(t))
+	case *types.Named:
+		if w.seen[t] {
+			return "", false
+		}
+		w.seen[t] = true
+		if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			return "", false
+		}
+		if w.pass.ImportObjectFact(t.Obj(), new(allowed)) {
+			return "", false
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if u.Kind() == types.UnsafePointer {
+			return "unsafe.Pointer", true
+		}
+	case *types.Pointer:
+		return "pointer", true
+	case *types.Chan:
+		return "channel", true
+	case *types.Interface:
+		return "interface", true
+	case *types.Array:
+		if path, ok := w.ref(u.Elem()); ok {
+			return "array element: " + path, true
+		}
+	case *types.Struct:
+		for i := range u.NumFields() {
+			f := u.Field(i)
+			if path, ok := w.ref(f.Type()); ok {
+				return fmt.Sprintf("field %s: %s", f.Name(), path), true
+			}
+		}
+	}
+	return "", false
+}
+
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
+
+func hasDirective(g *ast.CommentGroup) bool {
+	for _, c := range g.List {
+		if isDirective(c.Text) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDirective(text string) bool {
+	rest, ok := strings.CutPrefix(text, directive)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
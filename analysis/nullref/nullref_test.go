@@ -0,0 +1,12 @@
+package nullref_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/null/analysis/nullref"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), nullref.Analyzer, "a")
+}
@@ -0,0 +1,84 @@
+package a
+
+import (
+	"time"
+	"unsafe"
+
+	"b"
+
+	"github.com/qawatake/null"
+)
+
+type Foo struct{ n int }
+
+type withMap struct {
+	m *map[string]int
+}
+
+type Nested struct {
+	Arr [2]struct {
+		Err error
+	}
+}
+
+type List struct {
+	Next *List
+}
+
+type Plain struct {
+	S string
+	T time.Time
+	A [3]int
+}
+
+type Alias = *Foo
+
+type Generic[V comparable] struct {
+	v V
+}
+
+var (
+	_ null.T[int]
+	_ null.T[string]
+	_ null.T[time.Time]
+	_ null.T[Plain]
+	_ null.T[b.Handle]
+	_ null.T[b.Opaque] // want `payload b.Opaque of null.T carries a reference \(field m: pointer\)`
+
+	_ null.T[*Foo]                  // want `payload \*Foo of null.T carries a reference \(pointer\)`
+	_ null.T[withMap]               // want `payload withMap of null.T carries a reference \(field m: pointer\)`
+	_ null.T[Nested]                // want `\(field Arr: array element: field Err: interface\)`
+	_ null.T[List]                  // want `\(field Next: pointer\)`
+	_ null.T[any]                   // want `\(interface\)`
+	_ null.T[chan int]              // want `\(channel\)`
+	_ null.T[unsafe.Pointer]        // want `\(unsafe.Pointer\)`
+	_ null.T[Alias]                 // want `\(pointer\)`
+	_ null.T[b.Open]                // want `payload b.Open of null.T carries a reference \(field P: pointer\)`
+	_ null.T[Generic[*int]]         // want `\(field v: pointer\)`
+	_ = null.From(&Foo{})           // want `payload \*Foo`
+	_ = null.From[*Foo](nil)        // want `payload \*Foo`
+	_ = null.FromPtr(new(chan int)) // want `\(channel\)`
+
+	_ null.T[*Foo] //nullref:allow
+	_ null.T[*Foo] // want `payload \*Foo`
+	//nullref:allow shared on purpose
+	_ = null.From(&Foo{})
+	_ null.T[*Foo] //nullref:allowed // want `payload \*Foo`
+)
+
+func generic[V comparable](v V) null.T[V] {
+	return null.From(v)
+}
+
+type Logger struct{ name string }
+
+type Config struct {
+	Log *Logger
+}
+
+// The type argument of Coalesce is not a payload.
+func merge(a, b Config) Config {
+	merged, _ := null.Coalesce(a, b)
+	merged, _ = null.Coalesce[Config](a, b)
+	return merged
+}
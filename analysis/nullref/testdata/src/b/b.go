@@ -0,0 +1,16 @@
+package b
+
+// Handle is shared on purpose.
+//
+//nullref:allow
+type Handle struct {
+	p *int
+}
+
+type Opaque struct {
+	m *int
+}
+
+type Open struct {
+	P *int
+}
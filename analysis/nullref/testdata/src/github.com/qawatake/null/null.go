@@ -0,0 +1,22 @@
+// Package null is a stub of github.com/qawatake/null for tests.
+package null
+
+type T[V comparable] struct {
+	v     V
+	valid bool
+}
+
+func From[V comparable](v V) T[V] {
+	return T[V]{v: v, valid: true}
+}
+
+func FromPtr[V comparable](p *V) T[V] {
+	if p == nil {
+		return T[V]{}
+	}
+	return From(*p)
+}
+
+func Coalesce[S any](layers ...S) (merged S, from map[string]int) {
+	return layers[0], nil
+}
@@ -0,0 +1,95 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Nullable is implemented by T[V] and Sensitive[V] for every V,
+// so that code handling values of type any can detect and unwrap them without knowing V:
+//
+//	if n, ok := v.(null.Nullable); ok {
+//		if n.IsNull() {
+//			// ...
+//		}
+//		payload := n.Any()
+//	}
+//
+// It cannot be implemented outside this package.
+type Nullable interface {
+	// IsNull reports whether the value is null.
+	IsNull() bool
+	// Any returns the payload, or nil if the value is null.
+	Any() any
+	// PayloadType returns the type V of the payload.
+	PayloadType() reflect.Type
+
+	// fromAny returns a new value of the same type holding v, or null if v is nil.
+	fromAny(v any) (Nullable, error)
+}
+
+var (
+	_ Nullable = T[int]{}
+	_ Nullable = Sensitive[int]{}
+)
+
+// Any returns the payload as any, or nil if t is null.
+// Note that a T[any] holding nil also returns nil; use IsNull to tell them apart.
+func (t T[V]) Any() any {
+	if t.IsNull() {
+		return nil
+	}
+	return t.v.V
+}
+
+// PayloadType returns the type V.
+func (t T[V]) PayloadType() reflect.Type {
+	return reflect.TypeOf((*V)(nil)).Elem()
+}
+
+func (t T[V]) fromAny(v any) (Nullable, error) {
+	if v == nil {
+		return T[V]{}, nil
+	}
+	p, ok := v.(V)
+	if !ok {
+		return nil, fmt.Errorf("null: cannot use %T as %v", v, t.PayloadType())
+	}
+	return From(p), nil
+}
+
+func (s Sensitive[V]) fromAny(v any) (Nullable, error) {
+	t, err := s.T.fromAny(v)
+	if err != nil {
+		return nil, err
+	}
+	return Sensitive[V]{T: t.(T[V])}, nil
+}
+
+var nullableType = reflect.TypeOf((*Nullable)(nil)).Elem()
+
+// FromAny returns a new value of type typ, which must be an instance of T or Sensitive, holding v.
+// It is null if v is nil. Otherwise, v must be of the payload type.
+// It is for code which knows the type only at run time, such as the type of a struct field:
+//
+//	n, err := null.FromAny(field.Type, payload)
+//	if err != nil {
+//		return err
+//	}
+//	rv.FieldByIndex(field.Index).Set(reflect.ValueOf(n))
+func FromAny(typ reflect.Type, v any) (Nullable, error) {
+	if !isNullable(typ) {
+		return nil, fmt.Errorf("null: %v is not a nullable type of this package", typ)
+	}
+	return reflect.Zero(typ).Interface().(Nullable).fromAny(v)
+}
+
+// isNullable reports whether t is an instance of T or Sensitive.
+// A struct type embedding one implements Nullable through the promoted methods, but is not one.
+func isNullable(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Struct || !t.Implements(nullableType) {
+		return false
+	}
+	n, _ := reflect.Zero(t).Interface().(Nullable).fromAny(nil)
+	return reflect.TypeOf(n) == t
+}
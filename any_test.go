@@ -0,0 +1,110 @@
+package null_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+func TestNullable(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    null.Nullable
+		wantNull bool
+		wantAny  any
+		wantType reflect.Type
+	}{
+		{name: "null", value: null.T[int]{}, wantNull: true, wantAny: nil, wantType: reflect.TypeOf(0)},
+		{name: "int", value: null.From(3), wantNull: false, wantAny: 3, wantType: reflect.TypeOf(0)},
+		{name: "zero", value: null.From(""), wantNull: false, wantAny: "", wantType: reflect.TypeOf("")},
+		{name: "time", value: null.From(time.Unix(0, 0)), wantNull: false, wantAny: time.Unix(0, 0), wantType: reflect.TypeOf(time.Time{})},
+		{name: "null any", value: null.T[any]{}, wantNull: true, wantAny: nil, wantType: reflect.TypeOf((*any)(nil)).Elem()},
+		{name: "nil any", value: null.From[any](nil), wantNull: false, wantAny: nil, wantType: reflect.TypeOf((*any)(nil)).Elem()},
+		{name: "sensitive null", value: null.Sensitive[string]{}, wantNull: true, wantAny: nil, wantType: reflect.TypeOf("")},
+		{name: "sensitive", value: null.Sensitive[string]{T: null.From("x")}, wantNull: false, wantAny: "x", wantType: reflect.TypeOf("")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, tt.value.IsNull(), tt.wantNull)
+			assertEqual(t, tt.value.Any(), tt.wantAny)
+			if got := tt.value.PayloadType(); got != tt.wantType {
+				t.Errorf("PayloadType() = %v, want %v", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestFromAny(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   reflect.Type
+		value any
+		want  null.Nullable
+	}{
+		{name: "null", typ: reflect.TypeOf(null.T[int]{}), value: nil, want: null.T[int]{}},
+		{name: "int", typ: reflect.TypeOf(null.T[int]{}), value: 3, want: null.From(3)},
+		{name: "zero", typ: reflect.TypeOf(null.T[string]{}), value: "", want: null.From("")},
+		{name: "any", typ: reflect.TypeOf(null.T[any]{}), value: 3, want: null.From[any](3)},
+		{name: "sensitive null", typ: reflect.TypeOf(null.Sensitive[string]{}), value: nil, want: null.Sensitive[string]{}},
+		{name: "sensitive", typ: reflect.TypeOf(null.Sensitive[string]{}), value: "x", want: null.Sensitive[string]{T: null.From("x")}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := null.FromAny(tt.typ, tt.value)
+			requireNoError(t, err)
+			if got != tt.want {
+				t.Errorf("FromAny(%v, %#v) = %#v, want %#v", tt.typ, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// UserID implements null.Nullable through the methods promoted from null.T.
+type UserID struct{ null.T[int64] }
+
+func TestFromAny_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   reflect.Type
+		value any
+	}{
+		{name: "nil type", typ: nil, value: 3},
+		{name: "not nullable", typ: reflect.TypeOf(0), value: 3},
+		{name: "pointer", typ: reflect.TypeOf(&null.T[int]{}), value: 3},
+		{name: "mismatched payload", typ: reflect.TypeOf(null.T[int]{}), value: int64(3)},
+		{name: "mismatched sensitive payload", typ: reflect.TypeOf(null.Sensitive[string]{}), value: 3},
+		{name: "embedding T", typ: reflect.TypeOf(UserID{}), value: int64(3)},
+		{name: "embedding Sensitive", typ: reflect.TypeOf(struct{ null.Sensitive[string] }{}), value: "x"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := null.FromAny(tt.typ, tt.value)
+			requireError(t, err)
+		})
+	}
+}
+
+func TestFromAny_Field(t *testing.T) {
+	// Reflection-driven code sets a field of a type unknown at compile time.
+	var user struct {
+		Name null.T[string]
+		Age  null.T[int]
+	}
+	rv := reflect.ValueOf(&user).Elem()
+	for name, payload := range map[string]any{"Name": "alice", "Age": nil} {
+		f := rv.FieldByName(name)
+		n, err := null.FromAny(f.Type(), payload)
+		requireNoError(t, err)
+		f.Set(reflect.ValueOf(n))
+	}
+	assertEqual(t, user.Name, null.From("alice"))
+	assertEqual(t, user.Age, null.T[int]{})
+}
@@ -0,0 +1,166 @@
+package null
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Atomic is a T which may be accessed by multiple goroutines simultaneously.
+// The zero value is null and ready to use. An Atomic must not be copied after first use.
+//
+// Atomic is lock-free for payloads of at most 64 bits with no pointers,
+// that is, of kinds bool, intN, uintN, floatN and complex64.
+// Payloads of at most 32 bits are stored with their validity in a single word without allocation.
+// Storing a payload of 64 bits allocates.
+// For other payloads, Atomic uses a mutex.
+type Atomic[V comparable] struct {
+	// packed holds the payload in the lower 32 bits and the validity in bit 32.
+	packed atomic.Uint64
+	// boxed points to the value, or is nil for null.
+	boxed atomic.Pointer[T[V]]
+
+	mu sync.Mutex
+	t  T[V]
+}
+
+// atomicMode is the representation used by Atomic.
+type atomicMode int
+
+const (
+	atomicLocked atomicMode = iota
+	atomicPacked
+	atomicBoxed
+)
+
+func modeOf[V comparable]() atomicMode {
+	switch reflect.TypeOf((*V)(nil)).Elem().Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Float32:
+		return atomicPacked
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uintptr,
+		reflect.Float64, reflect.Complex64:
+		return atomicBoxed
+	default:
+		return atomicLocked
+	}
+}
+
+const validBit = 1 << 32
+
+func pack[V comparable](t T[V]) uint64 {
+	if t.IsNull() {
+		return 0
+	}
+	var w uint32
+	*(*V)(unsafe.Pointer(&w)) = t.v.V
+	return uint64(w) | validBit
+}
+
+func unpack[V comparable](u uint64) T[V] {
+	if u&validBit == 0 {
+		return T[V]{}
+	}
+	w := uint32(u)
+	return From(*(*V)(unsafe.Pointer(&w)))
+}
+
+func box[V comparable](t T[V]) *T[V] {
+	if t.IsNull() {
+		return nil
+	}
+	return &t
+}
+
+func unbox[V comparable](p *T[V]) T[V] {
+	if p == nil {
+		return T[V]{}
+	}
+	return *p
+}
+
+// Load returns the value of a.
+func (a *Atomic[V]) Load() T[V] {
+	switch modeOf[V]() {
+	case atomicPacked:
+		return unpack[V](a.packed.Load())
+	case atomicBoxed:
+		return unbox(a.boxed.Load())
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.t
+}
+
+// Store sets the value of a to t.
+func (a *Atomic[V]) Store(t T[V]) {
+	switch modeOf[V]() {
+	case atomicPacked:
+		a.packed.Store(pack(t))
+		return
+	case atomicBoxed:
+		a.boxed.Store(box(t))
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.t = t
+}
+
+// Swap sets the value of a to t and returns the previous value.
+func (a *Atomic[V]) Swap(t T[V]) (old T[V]) {
+	switch modeOf[V]() {
+	case atomicPacked:
+		return unpack[V](a.packed.Swap(pack(t)))
+	case atomicBoxed:
+		return unbox(a.boxed.Swap(box(t)))
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	old, a.t = a.t, t
+	return old
+}
+
+// CompareAndSwap sets the value of a to new if the value is equal to old in the sense of [T.Equal],
+// and reports whether it did.
+func (a *Atomic[V]) CompareAndSwap(old, new T[V]) (swapped bool) {
+	switch modeOf[V]() {
+	case atomicPacked:
+		// T.Equal may differ from the equality of the representations, for example for -0 and NaN.
+		// The loop retries while the value changes between the load and the swap.
+		p := pack(new)
+		for {
+			cur := a.packed.Load()
+			if !unpack[V](cur).Equal(old) {
+				return false
+			}
+			if a.packed.CompareAndSwap(cur, p) {
+				return true
+			}
+		}
+	case atomicBoxed:
+		p := box(new)
+		for {
+			cur := a.boxed.Load()
+			if !unbox(cur).Equal(old) {
+				return false
+			}
+			if a.boxed.CompareAndSwap(cur, p) {
+				return true
+			}
+		}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.t.Equal(old) {
+		return false
+	}
+	a.t = new
+	return true
+}
+
+// Clear sets the value of a to null.
+func (a *Atomic[V]) Clear() {
+	a.Store(T[V]{})
+}
@@ -0,0 +1,177 @@
+package null_test
+
+import (
+	"math"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+func TestAtomic(t *testing.T) {
+	t.Run("int8", func(t *testing.T) { testAtomic(t, int8(-1), int8(2)) })
+	t.Run("uint32", func(t *testing.T) { testAtomic(t, uint32(math.MaxUint32), uint32(0)) })
+	t.Run("bool", func(t *testing.T) { testAtomic(t, true, false) })
+	t.Run("float32", func(t *testing.T) { testAtomic(t, float32(1.5), float32(0)) })
+	t.Run("int64", func(t *testing.T) { testAtomic(t, int64(math.MinInt64), int64(0)) })
+	t.Run("float64", func(t *testing.T) { testAtomic(t, 1.5, 0.0) })
+	t.Run("string", func(t *testing.T) { testAtomic(t, "a", "") })
+	t.Run("time", func(t *testing.T) { testAtomic(t, time.Unix(1, 0).UTC(), time.Time{}) })
+	t.Run("array", func(t *testing.T) { testAtomic(t, [2]int{1, 2}, [2]int{}) })
+}
+
+// testAtomic checks the methods sequentially with two distinct payloads x and y, where y may be the zero value.
+func testAtomic[V comparable](t *testing.T, x, y V) {
+	t.Helper()
+	var a null.Atomic[V]
+	assertEqual(t, a.Load(), null.T[V]{})
+
+	a.Store(null.From(x))
+	assertEqual(t, a.Load(), null.From(x))
+
+	assertEqual(t, a.Swap(null.From(y)), null.From(x))
+	assertEqual(t, a.Load(), null.From(y))
+
+	// y may be the zero value, which must not be confused with null.
+	assertEqual(t, a.CompareAndSwap(null.T[V]{}, null.From(x)), false)
+	assertEqual(t, a.Load(), null.From(y))
+	assertEqual(t, a.CompareAndSwap(null.From(y), null.From(x)), true)
+	assertEqual(t, a.Load(), null.From(x))
+
+	a.Clear()
+	assertEqual(t, a.Load(), null.T[V]{})
+	assertEqual(t, a.CompareAndSwap(null.T[V]{}, null.From(y)), true)
+	assertEqual(t, a.Swap(null.T[V]{}), null.From(y))
+	assertEqual(t, a.Load().IsNull(), true)
+}
+
+func TestAtomic_CompareAndSwapByEqual(t *testing.T) {
+	// Same instant in different locations.
+	var tm null.Atomic[time.Time]
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tm.Store(null.From(at))
+	assertEqual(t, tm.CompareAndSwap(null.From(at.In(time.FixedZone("JST", 9*60*60))), null.T[time.Time]{}), true)
+
+	// -0 == 0, but their representations differ.
+	var f32 null.Atomic[float32]
+	f32.Store(null.From(float32(math.Copysign(0, -1))))
+	assertEqual(t, f32.CompareAndSwap(null.From(float32(0)), null.From(float32(1))), true)
+	var f64 null.Atomic[float64]
+	f64.Store(null.From(math.Copysign(0, -1)))
+	assertEqual(t, f64.CompareAndSwap(null.From(0.0), null.From(1.0)), true)
+
+	// NaN != NaN, but their representations are equal.
+	f64.Store(null.From(math.NaN()))
+	assertEqual(t, f64.CompareAndSwap(null.From(math.NaN()), null.From(1.0)), false)
+}
+
+func TestAtomic_Allocs(t *testing.T) {
+	var a null.Atomic[int32]
+	v := null.From[int32](1)
+	allocs := testing.AllocsPerRun(100, func() {
+		a.Store(v)
+		_ = a.Load()
+		_ = a.Swap(v)
+		_ = a.CompareAndSwap(v, v)
+	})
+	assertEqual(t, allocs, 0.0)
+
+	var b null.Atomic[int64]
+	b.Store(null.From[int64](1))
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = b.Load()
+	})
+	assertEqual(t, allocs, 0.0)
+}
+
+// TestAtomic_Concurrent increments a counter by CompareAndSwap from many goroutines.
+// Run it with -race.
+func TestAtomic_Concurrent(t *testing.T) {
+	t.Run("int16", func(t *testing.T) {
+		testAtomicConcurrent(t, func(v int16) int16 { return v + 1 }, func(n int) int16 { return int16(n) })
+	})
+	t.Run("int", func(t *testing.T) {
+		testAtomicConcurrent(t, func(v int) int { return v + 1 }, func(n int) int { return n })
+	})
+	t.Run("string", func(t *testing.T) {
+		next := func(v string) string {
+			n, _ := strconv.Atoi(v)
+			return strconv.Itoa(n + 1)
+		}
+		testAtomicConcurrent(t, next, strconv.Itoa)
+	})
+}
+
+func testAtomicConcurrent[V comparable](t *testing.T, next func(V) V, count func(n int) V) {
+	t.Helper()
+	const (
+		goroutines = 8
+		increments = 200
+	)
+	var a null.Atomic[V]
+	var zero V
+	a.Store(null.From(zero))
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				for {
+					// The value is null while goroutine 0 holds it below.
+					old := a.Load()
+					if !old.IsNull() && a.CompareAndSwap(old, null.From(next(old.ValueOrZero()))) {
+						break
+					}
+					runtime.Gosched()
+				}
+				// Other methods run at the same time.
+				if g == 0 {
+					old := a.Swap(null.T[V]{})
+					a.Store(old)
+				}
+			}
+		}(g)
+	}
+	// A reader never observes a torn value.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			_ = a.Load()
+		}
+	}()
+	wg.Wait()
+	<-done
+	assertEqual(t, a.Load(), null.From(count(goroutines*increments)))
+}
+
+// TestAtomic_NoTear checks that a struct payload stored by a mutex is never read half-written.
+func TestAtomic_NoTear(t *testing.T) {
+	type pair struct{ A, B int }
+	var a null.Atomic[pair]
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				a.Store(null.From(pair{A: g*1000 + i, B: g*1000 + i}))
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if p := a.Load().ValueOrZero(); p.A != p.B {
+					t.Errorf("torn read: %+v", p)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,16 @@
+// Package civil provides comparable types for civil dates and times of day,
+// suitable as payloads of null.T for SQL DATE and TIME columns.
+//
+// Unlike time.Time, these types carry neither a time zone nor an instant,
+// so null.T[civil.Date] round-trips 2024-03-10 as 2024-03-10 rather than as a midnight timestamp.
+package civil
+
+func compare[V ~int | ~int64](x, y V) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return +1
+	}
+	return 0
+}
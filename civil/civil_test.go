@@ -0,0 +1,212 @@
+package civil_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/civil"
+)
+
+func TestDate(t *testing.T) {
+	d := civil.Date{Year: 2024, Month: time.March, Day: 10}
+
+	t.Run("String", func(t *testing.T) {
+		assertEqual(t, d.String(), "2024-03-10")
+		assertEqual(t, civil.Date{Year: 7, Month: time.January, Day: 2}.String(), "0007-01-02")
+	})
+
+	t.Run("IsValid", func(t *testing.T) {
+		assertEqual(t, d.IsValid(), true)
+		assertEqual(t, civil.Date{}.IsValid(), false)
+		assertEqual(t, civil.Date{Year: 2023, Month: time.February, Day: 29}.IsValid(), false)
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		assertEqual(t, d.AddDays(-10), civil.Date{Year: 2024, Month: time.February, Day: 29})
+		assertEqual(t, d.AddMonths(10), civil.Date{Year: 2025, Month: time.January, Day: 10})
+		assertEqual(t, d.DaysSince(civil.Date{Year: 2023, Month: time.March, Day: 10}), 366)
+		assertEqual(t, civil.Date{Year: 2024, Month: time.January, Day: 1}.DaysSince(civil.Date{Year: 1, Month: time.January, Day: 1}), 738885)
+		assertEqual(t, civil.Date{Year: 1, Month: time.January, Day: 1}.DaysSince(civil.Date{Year: 2024, Month: time.January, Day: 1}), -738885)
+		assertEqual(t, civil.Date{Year: 1969, Month: time.December, Day: 31}.DaysSince(civil.Date{Year: 1970, Month: time.January, Day: 2}), -2)
+		assertEqual(t, d.Before(d.AddDays(1)), true)
+		assertEqual(t, d.After(d.AddDays(1)), false)
+		assertEqual(t, d.Compare(d), 0)
+	})
+
+	t.Run("invalid dates are not encoded", func(t *testing.T) {
+		for _, d := range []civil.Date{{}, {Year: 2023, Month: time.February, Day: 29}} {
+			_, err := d.MarshalText()
+			requireError(t, err)
+			_, err = d.Value()
+			requireError(t, err)
+			_, err = json.Marshal(null.From(d))
+			requireError(t, err)
+		}
+	})
+
+	t.Run("DateOf ignores DST", func(t *testing.T) {
+		ny, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skip(err)
+		}
+		// DST starts on 2024-03-10 in New York.
+		assertEqual(t, civil.DateOf(d.In(ny)), d)
+		assertEqual(t, civil.DateOf(d.In(ny).Add(24*time.Hour)), d.AddDays(1))
+	})
+}
+
+func TestTimeOfDay(t *testing.T) {
+	tod := civil.TimeOfDay{Hour: 23, Minute: 4, Second: 5, Nanosecond: 6000}
+
+	t.Run("String", func(t *testing.T) {
+		assertEqual(t, tod.String(), "23:04:05.000006")
+		assertEqual(t, civil.TimeOfDay{Hour: 1}.String(), "01:00:00")
+		assertEqual(t, civil.TimeOfDay{Nanosecond: 1}.String(), "00:00:00.000000001")
+	})
+
+	t.Run("IsValid", func(t *testing.T) {
+		assertEqual(t, tod.IsValid(), true)
+		assertEqual(t, civil.TimeOfDay{Hour: 24}.IsValid(), false)
+	})
+
+	t.Run("invalid times of day are not encoded", func(t *testing.T) {
+		for _, tod := range []civil.TimeOfDay{{Hour: 24}, {Minute: 60}, {Second: -1}, {Nanosecond: 1e9}} {
+			_, err := tod.MarshalText()
+			requireError(t, err)
+			_, err = tod.Value()
+			requireError(t, err)
+			_, err = json.Marshal(null.From(tod))
+			requireError(t, err)
+		}
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		assertEqual(t, tod.Add(time.Hour), civil.TimeOfDay{Hour: 0, Minute: 4, Second: 5, Nanosecond: 6000})
+		assertEqual(t, tod.Add(-24*time.Hour-time.Minute), civil.TimeOfDay{Hour: 23, Minute: 3, Second: 5, Nanosecond: 6000})
+		assertEqual(t, tod.Sub(civil.TimeOfDay{Hour: 22, Minute: 4, Second: 5}), time.Hour+6*time.Microsecond)
+		assertEqual(t, tod.Before(civil.TimeOfDay{}), false)
+		assertEqual(t, tod.After(civil.TimeOfDay{}), true)
+		assertEqual(
+			t,
+			tod.On(civil.Date{Year: 2024, Month: time.March, Day: 10}, time.UTC),
+			time.Date(2024, 3, 10, 23, 4, 5, 6000, time.UTC),
+		)
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Run("Date", func(t *testing.T) {
+		want := null.From(civil.Date{Year: 2024, Month: time.March, Day: 10})
+		tests := []struct {
+			name    string
+			src     any
+			want    null.T[civil.Date]
+			wantErr bool
+		}{
+			{name: "time.Time", src: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), want: want},
+			{name: "string", src: "2024-03-10", want: want},
+			{name: "[]byte", src: []byte("2024-03-10"), want: want},
+			{name: "nil", src: nil},
+			{name: "invalid string", src: "2024-02-30", wantErr: true},
+			{name: "int64", src: int64(20240310), wantErr: true},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				var got null.T[civil.Date]
+				err := got.Scan(tt.src)
+				assertEqual(t, err != nil, tt.wantErr)
+				assertEqual(t, got == tt.want, true)
+			})
+		}
+	})
+
+	t.Run("TimeOfDay", func(t *testing.T) {
+		want := null.From(civil.TimeOfDay{Hour: 12, Minute: 4, Second: 5, Nanosecond: 500000000})
+		tests := []struct {
+			name    string
+			src     any
+			want    null.T[civil.TimeOfDay]
+			wantErr bool
+		}{
+			{name: "time.Time", src: time.Date(0, 1, 1, 12, 4, 5, 500000000, time.UTC), want: want},
+			{name: "string", src: "12:04:05.5", want: want},
+			{name: "[]byte", src: []byte("12:04:05.500000"), want: want},
+			{name: "string with date", src: "0000-01-01T12:04:05.5", want: want},
+			{name: "nil", src: nil},
+			{name: "invalid string", src: "25:00:00", wantErr: true},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				var got null.T[civil.TimeOfDay]
+				err := got.Scan(tt.src)
+				assertEqual(t, err != nil, tt.wantErr)
+				assertEqual(t, got == tt.want, true)
+			})
+		}
+	})
+}
+
+func TestValue(t *testing.T) {
+	v, err := civil.Date{Year: 2024, Month: time.March, Day: 10}.Value()
+	requireNoError(t, err)
+	assertEqual(t, v, any("2024-03-10"))
+
+	v, err = civil.TimeOfDay{Hour: 12, Minute: 4, Second: 5, Nanosecond: 500000000}.Value()
+	requireNoError(t, err)
+	assertEqual(t, v, any("12:04:05.500000"))
+
+	v, err = null.T[civil.Date]{}.Value()
+	requireNoError(t, err)
+	assertEqual(t, v, nil)
+}
+
+func TestJSON(t *testing.T) {
+	type Shift struct {
+		Date  null.T[civil.Date]
+		Start null.T[civil.TimeOfDay]
+		End   null.T[civil.TimeOfDay]
+	}
+
+	data := []byte(`{"Date":"2024-03-10","Start":"09:00:00","End":null}`)
+	var s Shift
+	requireNoError(t, json.Unmarshal(data, &s))
+	assertEqual(t, s.Date == null.From(civil.Date{Year: 2024, Month: time.March, Day: 10}), true)
+	assertEqual(t, s.Start == null.From(civil.TimeOfDay{Hour: 9}), true)
+	assertEqual(t, s.End.IsNull(), true)
+
+	got, err := json.Marshal(s)
+	requireNoError(t, err)
+	assertEqual(t, string(got), string(data))
+
+	requireError(t, json.Unmarshal([]byte(`{"Date":"2024-03-10T00:00:00Z"}`), &s))
+}
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
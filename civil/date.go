@@ -0,0 +1,172 @@
+// Modified code from https://github.com/googleapis/google-cloud-go/blob/main/civil/civil.go
+// Adapted to strictly comparable types with database/sql support.
+
+// Copyright 2016 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package civil
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"time"
+)
+
+// Date represents a calendar date, independent of any time zone.
+// The zero value for Date is 0000-00-00, which is not valid.
+//
+// Date is strictly comparable, so values of type null.T[Date] can be compared with ==.
+type Date struct {
+	Year  int        // Year (e.g., 2014).
+	Month time.Month // Month of the year (January = 1, ...).
+	Day   int        // Day of the month, starting at 1.
+}
+
+// DateOf returns the Date in which t occurs in t's location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// ParseDate parses a string in YYYY-MM-DD format and returns the Date it represents.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// String returns d in YYYY-MM-DD format.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid reports whether d is a valid date.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the time corresponding to midnight at the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date that is n days after d. n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// AddMonths returns the date that is n months after d. n may be negative.
+// Like time.Time.AddDate, it normalizes overflowing days, so 2024-01-31 plus one month is 2024-03-02.
+func (d Date) AddMonths(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, n, 0))
+}
+
+// DaysSince returns the number of days from u to d.
+func (d Date) DaysSince(u Date) int {
+	// A time.Duration overflows beyond about 292 years, so the days are counted from the Unix epoch instead.
+	return int(d.daysSinceEpoch() - u.daysSinceEpoch())
+}
+
+// daysSinceEpoch returns the number of days from 1970-01-01 to d.
+func (d Date) daysSinceEpoch() int64 {
+	// Midnight in UTC is a multiple of a day since the epoch, so the division is exact.
+	return d.In(time.UTC).Unix() / secondsPerDay
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+// Compare compares d and u. It returns -1 if d is before u, 0 if they are the same date and +1 if d is after u.
+func (d Date) Compare(u Date) int {
+	switch {
+	case d.Year != u.Year:
+		return compare(d.Year, u.Year)
+	case d.Month != u.Month:
+		return compare(d.Month, u.Month)
+	default:
+		return compare(d.Day, u.Day)
+	}
+}
+
+// Before reports whether d is before u.
+func (d Date) Before(u Date) bool {
+	return d.Compare(u) < 0
+}
+
+// After reports whether d is after u.
+func (d Date) After(u Date) bool {
+	return d.Compare(u) > 0
+}
+
+var _ encoding.TextMarshaler = Date{}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The output is the result of d.String().
+// It returns an error if d is not valid, such as the zero Date, since UnmarshalText would reject the output.
+func (d Date) MarshalText() ([]byte, error) {
+	if !d.IsValid() {
+		return nil, errInvalidDate(d)
+	}
+	return []byte(d.String()), nil
+}
+
+func errInvalidDate(d Date) error {
+	return fmt.Errorf("civil: invalid date %v", d)
+}
+
+var _ encoding.TextUnmarshaler = &Date{}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The date is expected to be in YYYY-MM-DD format.
+func (d *Date) UnmarshalText(data []byte) error {
+	v, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+var _ sql.Scanner = &Date{}
+
+// Scan implements the sql.Scanner interface.
+// It accepts time.Time and text in YYYY-MM-DD format.
+// A time.Time is converted to the date in its own location.
+func (d *Date) Scan(src any) error {
+	switch s := src.(type) {
+	case time.Time:
+		*d = DateOf(s)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(s))
+	case []byte:
+		return d.UnmarshalText(s)
+	}
+	return fmt.Errorf("civil: unsupported Scan, storing driver.Value type %T into type %T", src, d)
+}
+
+var _ driver.Valuer = Date{}
+
+// Value implements the driver.Valuer interface.
+// It returns d in YYYY-MM-DD format, or an error if d is not valid.
+func (d Date) Value() (driver.Value, error) {
+	if !d.IsValid() {
+		return nil, errInvalidDate(d)
+	}
+	return d.String(), nil
+}
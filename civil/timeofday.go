@@ -0,0 +1,182 @@
+// Modified code from https://github.com/googleapis/google-cloud-go/blob/main/civil/civil.go
+// Adapted to strictly comparable types with database/sql support.
+
+// Copyright 2016 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package civil
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay represents a time of day with nanosecond precision, independent of any date or time zone.
+// The zero value for TimeOfDay is midnight.
+//
+// TimeOfDay is strictly comparable, so values of type null.T[TimeOfDay] can be compared with ==.
+type TimeOfDay struct {
+	Hour       int // The hour of the day in 24-hour format; range [0-23]
+	Minute     int // The minute of the hour; range [0-59]
+	Second     int // The second of the minute; range [0-59]
+	Nanosecond int // The nanosecond of the second; range [0-999999999]
+}
+
+// TimeOf returns the TimeOfDay at which t occurs in t's location.
+func TimeOf(t time.Time) TimeOfDay {
+	var tod TimeOfDay
+	tod.Hour, tod.Minute, tod.Second = t.Clock()
+	tod.Nanosecond = t.Nanosecond()
+	return tod
+}
+
+// ParseTimeOfDay parses a string in HH:MM:SS[.fffffffff] format and returns the TimeOfDay it represents.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04:05.999999999", s)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return TimeOf(t), nil
+}
+
+// String returns tod in HH:MM:SS[.ffffff] format.
+// The fractional part is omitted if it is zero,
+// and is written with nine digits only if tod has sub-microsecond precision.
+func (tod TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", tod.Hour, tod.Minute, tod.Second)
+	switch {
+	case tod.Nanosecond == 0:
+		return s
+	case tod.Nanosecond%1000 == 0:
+		return s + fmt.Sprintf(".%06d", tod.Nanosecond/1000)
+	default:
+		return s + fmt.Sprintf(".%09d", tod.Nanosecond)
+	}
+}
+
+// IsValid reports whether tod is a valid time of day.
+func (tod TimeOfDay) IsValid() bool {
+	return TimeOf(tod.On(Date{Year: 2000, Month: time.January, Day: 1}, time.UTC)) == tod
+}
+
+// On returns the time at which tod occurs on d in loc.
+func (tod TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc)
+}
+
+// Add returns tod+dur, wrapping around midnight.
+func (tod TimeOfDay) Add(dur time.Duration) TimeOfDay {
+	dur = (tod.sinceMidnight() + dur%day + day) % day
+	return TimeOf(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC).Add(dur))
+}
+
+// Sub returns the duration tod-u, which is within (-24h, 24h).
+func (tod TimeOfDay) Sub(u TimeOfDay) time.Duration {
+	return tod.sinceMidnight() - u.sinceMidnight()
+}
+
+// Compare compares tod and u. It returns -1 if tod is before u, 0 if they are the same and +1 if tod is after u.
+func (tod TimeOfDay) Compare(u TimeOfDay) int {
+	return compare(tod.sinceMidnight(), u.sinceMidnight())
+}
+
+// Before reports whether tod is before u.
+func (tod TimeOfDay) Before(u TimeOfDay) bool {
+	return tod.Compare(u) < 0
+}
+
+// After reports whether tod is after u.
+func (tod TimeOfDay) After(u TimeOfDay) bool {
+	return tod.Compare(u) > 0
+}
+
+func (tod TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(tod.Hour)*time.Hour +
+		time.Duration(tod.Minute)*time.Minute +
+		time.Duration(tod.Second)*time.Second +
+		time.Duration(tod.Nanosecond)
+}
+
+var _ encoding.TextMarshaler = TimeOfDay{}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The output is the result of tod.String().
+// It returns an error if tod is not valid, such as 24:00:00, since UnmarshalText would reject the output.
+func (tod TimeOfDay) MarshalText() ([]byte, error) {
+	if !tod.IsValid() {
+		return nil, errInvalidTimeOfDay(tod)
+	}
+	return []byte(tod.String()), nil
+}
+
+func errInvalidTimeOfDay(tod TimeOfDay) error {
+	return fmt.Errorf("civil: invalid time of day %v", tod)
+}
+
+var _ encoding.TextUnmarshaler = &TimeOfDay{}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The time is expected to be in HH:MM:SS[.fffffffff] format.
+func (tod *TimeOfDay) UnmarshalText(data []byte) error {
+	v, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+	*tod = v
+	return nil
+}
+
+var _ sql.Scanner = &TimeOfDay{}
+
+// Scan implements the sql.Scanner interface.
+// It accepts time.Time and text in HH:MM:SS[.fffffffff] format.
+// A time.Time is converted to the time of day in its own location,
+// and text with a leading date, as some drivers return for TIME columns, is accepted as well.
+func (tod *TimeOfDay) Scan(src any) error {
+	switch s := src.(type) {
+	case time.Time:
+		*tod = TimeOf(s)
+		return nil
+	case string:
+		return tod.UnmarshalText([]byte(trimDate(s)))
+	case []byte:
+		return tod.UnmarshalText([]byte(trimDate(string(s))))
+	}
+	return fmt.Errorf("civil: unsupported Scan, storing driver.Value type %T into type %T", src, tod)
+}
+
+var _ driver.Valuer = TimeOfDay{}
+
+// Value implements the driver.Valuer interface.
+// It returns tod in HH:MM:SS[.ffffff] format, or an error if tod is not valid.
+func (tod TimeOfDay) Value() (driver.Value, error) {
+	if !tod.IsValid() {
+		return nil, errInvalidTimeOfDay(tod)
+	}
+	return tod.String(), nil
+}
+
+// trimDate removes a leading "YYYY-MM-DD " or "YYYY-MM-DDT" from s.
+func trimDate(s string) string {
+	if i := strings.IndexAny(s, " T"); i == len(time.DateOnly) {
+		return s[i+1:]
+	}
+	return s
+}
+
+const day = 24 * time.Hour
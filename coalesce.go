@@ -0,0 +1,68 @@
+package null
+
+import "reflect"
+
+// Coalesce merges layers of a struct type S, the first layer taking precedence,
+// as for configuration read from flags, environment variables, a file and defaults:
+//
+//	cfg, from := null.Coalesce(flags, env, file, defaults)
+//
+// Each exported field of type T or Sensitive is set from the first layer in which it is not null,
+// and is null if it is null in every layer.
+// Exported fields of other struct types, including embedded ones, are merged recursively.
+// Other fields, such as pointers or unexported fields, are copied from the first layer.
+//
+// from maps the path of each field set from a layer, such as "DB.Host", to the index of the layer.
+// Fields null in every layer are not in from.
+//
+// Coalesce panics if S is not a struct type.
+func Coalesce[S any](layers ...S) (merged S, from map[string]int) {
+	rv := reflect.ValueOf(&merged).Elem()
+	if rv.Kind() != reflect.Struct {
+		panic("null: Coalesce of non-struct type " + rv.Type().String())
+	}
+	from = make(map[string]int)
+	if len(layers) == 0 {
+		return merged, from
+	}
+	merged = layers[0]
+	vs := make([]reflect.Value, len(layers))
+	for i := range layers {
+		vs[i] = reflect.ValueOf(&layers[i]).Elem()
+	}
+	coalesce(rv, vs, "", from)
+	return merged, from
+}
+
+func coalesce(dst reflect.Value, layers []reflect.Value, path string, from map[string]int) {
+	t := dst.Type()
+	if isNullable(t) {
+		for i, l := range layers {
+			if !l.Interface().(Nullable).IsNull() {
+				dst.Set(l)
+				from[path] = i
+				return
+			}
+		}
+		dst.Set(reflect.Zero(t))
+		return
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	fields := make([]reflect.Value, len(layers))
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		for j, l := range layers {
+			fields[j] = l.Field(i)
+		}
+		p := f.Name
+		if path != "" {
+			p = path + "." + f.Name
+		}
+		coalesce(dst.Field(i), fields, p, from)
+	}
+}
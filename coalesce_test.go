@@ -0,0 +1,149 @@
+package null_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+)
+
+type dbConfig struct {
+	Host     null.T[string]
+	Port     null.T[int]
+	Password null.Sensitive[string]
+}
+
+type Logging struct {
+	Level null.T[string]
+}
+
+type appConfig struct {
+	Name    null.T[string]
+	Timeout null.T[time.Duration]
+	DB      dbConfig
+	Logging
+	Tags   *[]string
+	secret string
+}
+
+func TestCoalesce(t *testing.T) {
+	tags := []string{"flag"}
+	flags := appConfig{Name: null.From("flag"), Tags: &tags, secret: "flag"}
+	env := appConfig{DB: dbConfig{Host: null.From("env"), Password: null.Sensitive[string]{T: null.From("pw")}}}
+	file := appConfig{Name: null.From("file"), DB: dbConfig{Host: null.From("file"), Port: null.From(5432)}, Logging: Logging{Level: null.From("debug")}}
+	defaults := appConfig{Name: null.From("default"), DB: dbConfig{Port: null.From(3306)}, Logging: Logging{Level: null.From("info")}, secret: "default"}
+
+	got, from := null.Coalesce(flags, env, file, defaults)
+
+	want := appConfig{
+		Name:    null.From("flag"),
+		Timeout: null.T[time.Duration]{},
+		DB: dbConfig{
+			Host:     null.From("env"),
+			Port:     null.From(5432),
+			Password: null.Sensitive[string]{T: null.From("pw")},
+		},
+		Logging: Logging{Level: null.From("debug")},
+		Tags:    &tags,
+		secret:  "flag",
+	}
+	assertEqualStruct(t, got, want)
+	if diff := cmp.Diff(from, map[string]int{
+		"Name":          0,
+		"DB.Host":       1,
+		"DB.Port":       2,
+		"DB.Password":   1,
+		"Logging.Level": 2,
+	}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCoalesce_Layers(t *testing.T) {
+	tests := []struct {
+		name     string
+		layers   []dbConfig
+		want     dbConfig
+		wantFrom map[string]int
+	}{
+		{
+			name:     "no layers",
+			layers:   nil,
+			want:     dbConfig{},
+			wantFrom: map[string]int{},
+		},
+		{
+			name:     "single layer",
+			layers:   []dbConfig{{Host: null.From("a")}},
+			want:     dbConfig{Host: null.From("a")},
+			wantFrom: map[string]int{"Host": 0},
+		},
+		{
+			name:     "all null",
+			layers:   []dbConfig{{}, {}},
+			want:     dbConfig{},
+			wantFrom: map[string]int{},
+		},
+		{
+			name:     "zero payload is not null",
+			layers:   []dbConfig{{Port: null.From(0)}, {Port: null.From(80)}},
+			want:     dbConfig{Port: null.From(0)},
+			wantFrom: map[string]int{"Port": 0},
+		},
+		{
+			name:     "last layer",
+			layers:   []dbConfig{{}, {}, {Port: null.From(80)}},
+			want:     dbConfig{Port: null.From(80)},
+			wantFrom: map[string]int{"Port": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, from := null.Coalesce(tt.layers...)
+			assertEqualStruct(t, got, tt.want)
+			if diff := cmp.Diff(from, tt.wantFrom); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCoalesce_T(t *testing.T) {
+	got, from := null.Coalesce(null.T[int]{}, null.From(2), null.From(3))
+	assertEqual(t, got, null.From(2))
+	if diff := cmp.Diff(from, map[string]int{"": 1}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCoalesce_EmbeddedT(t *testing.T) {
+	// Cfg implements null.Nullable through the embedded T, but is merged field by field.
+	type Cfg struct {
+		null.T[int]
+		Name null.T[string]
+	}
+	got, from := null.Coalesce(Cfg{Name: null.From("a")}, Cfg{T: null.From(2)})
+	assertEqualStruct(t, got, Cfg{T: null.From(2), Name: null.From("a")})
+	if diff := cmp.Diff(from, map[string]int{"T": 1, "Name": 0}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCoalesce_DoesNotModifyLayers(t *testing.T) {
+	first := dbConfig{}
+	second := dbConfig{Host: null.From("b")}
+	null.Coalesce(first, second)
+	assertEqualStruct(t, first, dbConfig{})
+}
+
+func TestCoalesce_NonStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic, but got none")
+		}
+	}()
+	null.Coalesce(1, 2)
+}
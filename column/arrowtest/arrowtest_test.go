@@ -0,0 +1,97 @@
+// Package arrowtest tests the export of columns to Apache Arrow.
+// It is a separate module so that the null module does not depend on Arrow.
+package arrowtest_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/column"
+)
+
+func TestInt64(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ts := make([]null.T[int64], 1000)
+	for i := range ts {
+		if i%3 != 0 {
+			ts[i] = null.From(int64(i))
+		}
+	}
+	c := column.FromSlice(ts)
+
+	b := array.NewInt64Builder(mem)
+	defer b.Release()
+	c.AppendTo(b)
+	arr := b.NewInt64Array()
+	defer arr.Release()
+
+	if arr.Len() != len(ts) || arr.NullN() != c.NullCount() {
+		t.Fatalf("len: %d, nulls: %d, want len: %d, nulls: %d", arr.Len(), arr.NullN(), len(ts), c.NullCount())
+	}
+	for i, want := range ts {
+		got := null.T[int64]{}
+		if arr.IsValid(i) {
+			got = null.From(arr.Value(i))
+		}
+		if got != want {
+			t.Errorf("element %d: got %v, want %v", i, got, want)
+		}
+	}
+
+	// Import back from the Arrow buffers.
+	back := column.FromBitmap(arr.Int64Values(), arr.NullBitmapBytes(), arr.Data().Offset())
+	for i, want := range ts {
+		if back.At(i) != want {
+			t.Errorf("element %d: got %v, want %v", i, back.At(i), want)
+		}
+	}
+
+	// A sliced array starts within a byte of the null bitmap.
+	sliced := array.NewSlice(arr, 3, 998).(*array.Int64)
+	defer sliced.Release()
+	back = column.FromBitmap(sliced.Int64Values(), sliced.NullBitmapBytes(), sliced.Data().Offset())
+	for i, want := range ts[3:998] {
+		if back.At(i) != want {
+			t.Errorf("sliced element %d: got %v, want %v", i, back.At(i), want)
+		}
+	}
+	if back.NullCount() != sliced.NullN() {
+		t.Errorf("sliced nulls: got %d, want %d", back.NullCount(), sliced.NullN())
+	}
+}
+
+func TestString(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	c := column.FromSlice([]null.T[string]{null.From("a"), {}, null.From("")})
+	b := array.NewStringBuilder(mem)
+	defer b.Release()
+	c.AppendTo(b)
+	arr := b.NewStringArray()
+	defer arr.Release()
+
+	if got, want := arr.String(), `["a" (null) ""]`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestNoNulls(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	c := column.FromValues([]float64{1.5, 2}, nil)
+	b := array.NewFloat64Builder(mem)
+	defer b.Release()
+	c.AppendTo(b)
+	arr := b.NewFloat64Array()
+	defer arr.Release()
+
+	if arr.NullN() != 0 || arr.Value(0) != 1.5 || arr.Value(1) != 2 {
+		t.Errorf("got %v", arr)
+	}
+}
@@ -0,0 +1,233 @@
+// Package column provides Column, a columnar vector of nullable values
+// backed by a slice of values and a packed validity bitmap.
+//
+// A Column[V] takes the size of V plus one bit per element,
+// while a []null.T[V] takes a bool and padding in addition to each V.
+// The validity bitmap has the layout of Apache Arrow: bit i%8 of byte i/8 is set if element i is valid.
+package column
+
+import (
+	"math/bits"
+	"sync"
+
+	"github.com/qawatake/null"
+)
+
+// Column is a vector of nullable values. The zero value is an empty column ready to use.
+type Column[V comparable] struct {
+	values   []V
+	validity []byte
+	nulls    int
+}
+
+// Make returns an empty Column with capacity for n elements.
+func Make[V comparable](n int) Column[V] {
+	return Column[V]{
+		values:   make([]V, 0, n),
+		validity: make([]byte, 0, (n+7)/8),
+	}
+}
+
+// FromSlice returns a Column holding the elements of ts.
+func FromSlice[V comparable](ts []null.T[V]) Column[V] {
+	c := Make[V](len(ts))
+	for _, t := range ts {
+		c.Append(t)
+	}
+	return c
+}
+
+// FromValues returns a Column holding values, whose element i is null if valid[i] is false.
+// If valid is nil, all elements are valid. values is used by the Column without copying;
+// the elements at null positions are set to the zero value.
+// It panics if valid is not nil and the lengths differ.
+func FromValues[V comparable](values []V, valid []bool) Column[V] {
+	if valid != nil && len(valid) != len(values) {
+		panic("column: lengths of values and valid differ")
+	}
+	c := Column[V]{values: values, validity: make([]byte, (len(values)+7)/8)}
+	var zero V
+	for i := range values {
+		if valid == nil || valid[i] {
+			c.validity[i/8] |= 1 << (i % 8)
+		} else {
+			values[i] = zero
+			c.nulls++
+		}
+	}
+	return c
+}
+
+// FromBitmap returns a Column holding values, whose element i is null if bit j%8 of validity[j/8] is not set,
+// where j is offset+i. The offset lets the Column start within a byte of the bitmap,
+// as a sliced Arrow array does; for example, to import an Arrow array:
+//
+//	c := column.FromBitmap(arr.Int64Values(), arr.NullBitmapBytes(), arr.Data().Offset())
+//
+// If validity is nil, all elements are valid. values and validity are copied.
+// It panics if offset is negative or if validity is not nil and too short.
+func FromBitmap[V comparable](values []V, validity []byte, offset int) Column[V] {
+	if offset < 0 {
+		panic("column: negative offset")
+	}
+	n := len(values)
+	c := Column[V]{values: make([]V, n), validity: make([]byte, (n+7)/8)}
+	copy(c.values, values)
+	if validity == nil {
+		for i := range c.validity {
+			c.validity[i] = 0xff
+		}
+	} else {
+		if len(validity) < (offset+n+7)/8 {
+			panic("column: validity is too short")
+		}
+		start, shift := offset/8, offset%8
+		for i := range c.validity {
+			b := validity[start+i] >> shift
+			if shift != 0 && start+i+1 < len(validity) {
+				b |= validity[start+i+1] << (8 - shift)
+			}
+			c.validity[i] = b
+		}
+	}
+	if r := n % 8; r != 0 {
+		c.validity[n/8] &= 1<<r - 1
+	}
+	c.nulls = countNulls(c.validity, n)
+	if c.nulls > 0 {
+		var zero V
+		for i := range c.values {
+			if !c.IsValid(i) {
+				c.values[i] = zero
+			}
+		}
+	}
+	return c
+}
+
+// Len returns the number of elements.
+func (c Column[V]) Len() int {
+	return len(c.values)
+}
+
+// NullCount returns the number of null elements.
+func (c Column[V]) NullCount() int {
+	return c.nulls
+}
+
+// At returns element i. It panics if i is out of range.
+func (c Column[V]) At(i int) null.T[V] {
+	v := c.values[i]
+	if !c.IsValid(i) {
+		return null.T[V]{}
+	}
+	return null.From(v)
+}
+
+// IsValid reports whether element i is not null. It panics if i is out of range.
+func (c Column[V]) IsValid(i int) bool {
+	_ = c.values[i]
+	return c.validity[i/8]&(1<<(i%8)) != 0
+}
+
+// Append appends t.
+func (c *Column[V]) Append(t null.T[V]) {
+	if t.IsNull() {
+		c.AppendNull()
+		return
+	}
+	c.AppendValue(t.ValueOrZero())
+}
+
+// AppendValue appends a valid element v.
+func (c *Column[V]) AppendValue(v V) {
+	i := len(c.values)
+	c.values = append(c.values, v)
+	if i%8 == 0 {
+		c.validity = append(c.validity, 0)
+	}
+	c.validity[i/8] |= 1 << (i % 8)
+}
+
+// AppendNull appends a null element.
+func (c *Column[V]) AppendNull() {
+	var zero V
+	i := len(c.values)
+	c.values = append(c.values, zero)
+	if i%8 == 0 {
+		c.validity = append(c.validity, 0)
+	}
+	c.nulls++
+}
+
+// Values returns the values of the elements, which are the zero value at null positions.
+// The result shares memory with c.
+func (c Column[V]) Values() []V {
+	return c.values
+}
+
+// Validity returns the validity bitmap, in which bit i%8 of byte i/8 is set if element i is valid.
+// The bits beyond Len are zero. The result shares memory with c.
+func (c Column[V]) Validity() []byte {
+	return c.validity
+}
+
+// Slice returns the elements as a slice of null.T.
+func (c Column[V]) Slice() []null.T[V] {
+	ts := make([]null.T[V], len(c.values))
+	for i := range ts {
+		ts[i] = c.At(i)
+	}
+	return ts
+}
+
+// ValuesAppender is implemented by the typed builders of Apache Arrow,
+// such as *array.Int64Builder and *array.StringBuilder of github.com/apache/arrow-go.
+type ValuesAppender[V any] interface {
+	AppendValues(v []V, valid []bool)
+}
+
+// chunk is the number of elements passed to ValuesAppender at once.
+const chunk = 512
+
+// validPool holds buffers of validity passed to ValuesAppender,
+// which escape to the heap through the interface.
+var validPool = sync.Pool{
+	New: func() any { return new([chunk]bool) },
+}
+
+// AppendTo appends the elements of c to b, for example to export c to an Arrow array:
+//
+//	b := array.NewInt64Builder(memory.DefaultAllocator)
+//	defer b.Release()
+//	c.AppendTo(b)
+//	arr := b.NewInt64Array()
+//
+// It does not allocate per element; validity is passed in chunks through a pooled buffer.
+func (c Column[V]) AppendTo(b ValuesAppender[V]) {
+	if c.nulls == 0 {
+		b.AppendValues(c.values, nil)
+		return
+	}
+	valid := validPool.Get().(*[chunk]bool)
+	defer validPool.Put(valid)
+	for start := 0; start < len(c.values); start += chunk {
+		end := min(start+chunk, len(c.values))
+		for i := start; i < end; i++ {
+			valid[i-start] = c.validity[i/8]&(1<<(i%8)) != 0
+		}
+		b.AppendValues(c.values[start:end], valid[:end-start])
+	}
+}
+
+// countNulls returns the number of unset bits among the first n bits of validity.
+func countNulls(validity []byte, n int) int {
+	set := 0
+	for i := 0; i < n/8; i++ {
+		set += bits.OnesCount8(validity[i])
+	}
+	if r := n % 8; r != 0 {
+		set += bits.OnesCount8(validity[n/8] & (1<<r - 1))
+	}
+	return n - set
+}
@@ -0,0 +1,206 @@
+package column_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/column"
+)
+
+func TestRoundTrip(t *testing.T) {
+	f := func(values []int64, valid []bool) bool {
+		ts := make([]null.T[int64], len(values))
+		for i, v := range values {
+			if i < len(valid) && valid[i] {
+				ts[i] = null.From(v)
+			}
+		}
+		c := column.FromSlice(ts)
+		if c.Len() != len(ts) {
+			return false
+		}
+		nulls := 0
+		for i, t := range ts {
+			if c.At(i) != t || c.IsValid(i) == t.IsNull() {
+				return false
+			}
+			if t.IsNull() {
+				nulls++
+			}
+		}
+		if c.NullCount() != nulls {
+			return false
+		}
+
+		// Bitmap round trip, as an Arrow array would do.
+		c2 := column.FromBitmap(c.Values(), c.Validity(), 0)
+		return cmp.Equal(c.Slice(), ts) && cmp.Equal(c2.Slice(), ts) && c2.NullCount() == nulls
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestColumn(t *testing.T) {
+	var c column.Column[string]
+	c.AppendValue("a")
+	c.AppendNull()
+	c.Append(null.From(""))
+	c.Append(null.T[string]{})
+	for i := 0; i < 8; i++ {
+		c.AppendValue("x")
+	}
+
+	assertEqual(t, c.Len(), 12)
+	assertEqual(t, c.NullCount(), 2)
+	assertEqual(t, c.At(0), null.From("a"))
+	assertEqual(t, c.At(1).IsNull(), true)
+	assertEqual(t, c.At(2), null.From(""))
+	assertEqual(t, c.Validity(), []byte{0b1111_0101, 0b0000_1111})
+	assertEqual(t, c.Values()[:4], []string{"a", "", "", ""})
+
+	requirePanic(t, func() { c.At(12) })
+	requirePanic(t, func() { c.IsValid(-1) })
+}
+
+func TestFromValues(t *testing.T) {
+	values := []int{1, 2, 3}
+	c := column.FromValues(values, []bool{true, false, true})
+	assertEqual(t, c.Slice(), []null.T[int]{null.From(1), {}, null.From(3)})
+	assertEqual(t, values, []int{1, 0, 3})
+	assertEqual(t, c.NullCount(), 1)
+
+	c = column.FromValues([]int{4, 5}, nil)
+	assertEqual(t, c.Slice(), []null.T[int]{null.From(4), null.From(5)})
+
+	requirePanic(t, func() { column.FromValues([]int{1}, []bool{}) })
+}
+
+func TestFromBitmap(t *testing.T) {
+	// The bits beyond the length are ignored.
+	c := column.FromBitmap([]int{1, 2, 3}, []byte{0b1111_1101}, 0)
+	assertEqual(t, c.Slice(), []null.T[int]{null.From(1), {}, null.From(3)})
+	assertEqual(t, c.Values(), []int{1, 0, 3})
+	assertEqual(t, c.Validity(), []byte{0b101})
+	assertEqual(t, c.NullCount(), 1)
+
+	c = column.FromBitmap([]int{1, 2}, nil, 0)
+	assertEqual(t, c.Slice(), []null.T[int]{null.From(1), null.From(2)})
+	assertEqual(t, c.NullCount(), 0)
+
+	// The bitmap starts at bit 6, and spans two bytes.
+	c = column.FromBitmap([]int{1, 2, 3, 4}, []byte{0b1000_0000, 0b0000_0101}, 6)
+	assertEqual(t, c.Slice(), []null.T[int]{{}, null.From(2), null.From(3), {}})
+	assertEqual(t, c.Validity(), []byte{0b0110})
+	assertEqual(t, c.NullCount(), 2)
+
+	c = column.FromBitmap([]int{1, 2}, []byte{0, 0b10}, 8)
+	assertEqual(t, c.Slice(), []null.T[int]{{}, null.From(2)})
+
+	requirePanic(t, func() { column.FromBitmap(make([]int, 9), []byte{0xff}, 0) })
+	requirePanic(t, func() { column.FromBitmap(make([]int, 3), []byte{0xff}, 6) })
+	requirePanic(t, func() { column.FromBitmap(make([]int, 1), []byte{0xff}, -1) })
+}
+
+// builder mimics a typed builder of Apache Arrow.
+type builder[V any] struct {
+	values []V
+	valid  []bool
+	calls  int
+}
+
+func (b *builder[V]) AppendValues(v []V, valid []bool) {
+	b.calls++
+	b.values = append(b.values, v...)
+	if valid == nil {
+		for range v {
+			b.valid = append(b.valid, true)
+		}
+		return
+	}
+	b.valid = append(b.valid, valid...)
+}
+
+func TestAppendTo(t *testing.T) {
+	c := column.Make[int64](1500)
+	for i := 0; i < 1500; i++ {
+		if i%3 == 0 {
+			c.AppendNull()
+		} else {
+			c.AppendValue(int64(i))
+		}
+	}
+	var b builder[int64]
+	c.AppendTo(&b)
+	assertEqual(t, b.calls, 3)
+	assertEqual(t, len(b.values), 1500)
+	for i := range b.values {
+		assertEqual(t, b.valid[i], i%3 != 0)
+		if b.valid[i] {
+			assertEqual(t, b.values[i], int64(i))
+		}
+	}
+
+	// A column without nulls is appended at once.
+	b = builder[int64]{}
+	column.FromValues([]int64{1, 2}, nil).AppendTo(&b)
+	assertEqual(t, b.calls, 1)
+	assertEqual(t, b.valid, []bool{true, true})
+}
+
+type discard[V any] struct{}
+
+func (discard[V]) AppendValues([]V, []bool) {}
+
+func TestAppendTo_Allocs(t *testing.T) {
+	c := column.Make[int64](10000)
+	for i := 0; i < 10000; i++ {
+		if i%2 == 0 {
+			c.AppendNull()
+		} else {
+			c.AppendValue(int64(i))
+		}
+	}
+	var b column.ValuesAppender[int64] = discard[int64]{}
+	if allocs := testing.AllocsPerRun(10, func() { c.AppendTo(b) }); allocs > 0 {
+		t.Errorf("AppendTo allocates %v times", allocs)
+	}
+}
+
+func BenchmarkAppendTo(b *testing.B) {
+	c := column.Make[int64](1 << 16)
+	for i := 0; i < 1<<16; i++ {
+		if i%7 == 0 {
+			c.AppendNull()
+		} else {
+			c.AppendValue(int64(i))
+		}
+	}
+	var d column.ValuesAppender[int64] = discard[int64]{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.AppendTo(d)
+	}
+}
+
+func requirePanic(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic, but did not panic")
+		}
+	}()
+	f()
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package column_test
+
+import (
+	"fmt"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/column"
+)
+
+func ExampleColumn() {
+	var c column.Column[int]
+	c.AppendValue(1)
+	c.AppendNull()
+	c.Append(null.From(3))
+
+	fmt.Println(c.Len(), c.NullCount())
+	fmt.Println(c.Values())
+	fmt.Printf("%08b\n", c.Validity())
+	fmt.Println(c.At(1).IsNull(), c.At(2).ValueOrZero())
+	// Output:
+	// 3 1
+	// [1 0 3]
+	// [00000101]
+	// true 3
+}
+
+func ExampleFromBitmap() {
+	c := column.FromBitmap([]string{"a", "b", "c"}, []byte{0b011}, 0)
+	for _, t := range c.Slice() {
+		fmt.Println(t.Ptr() != nil, t.ValueOrZero())
+	}
+	// Output:
+	// true a
+	// true b
+	// false
+}
@@ -0,0 +1,142 @@
+// Package convert provides conversions between null.T and other nullable representations:
+// the Null types of database/sql, pointers, and slices and maps of them.
+//
+// The types of gopkg.in/guregu/null.v4 embed the database/sql types,
+// so they are converted through their embedded fields without this package depending on guregu/null:
+//
+//	n := convert.FromSQLNullInt64(g.NullInt64)           // guregu null.Int -> null.T[int64]
+//	g := gnull.Int{NullInt64: convert.ToSQLNullInt64(n)} // null.T[int64] -> guregu null.Int
+//
+// Converting to null.T discards the payload of an invalid database/sql value,
+// because a null T always holds the zero value.
+package convert
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+// FromSQLNullString converts s to null.T[string].
+func FromSQLNullString(s sql.NullString) null.T[string] {
+	return fromValid(s.String, s.Valid)
+}
+
+// ToSQLNullString converts t to sql.NullString.
+func ToSQLNullString(t null.T[string]) sql.NullString {
+	return sql.NullString{String: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// FromSQLNullInt64 converts i to null.T[int64].
+func FromSQLNullInt64(i sql.NullInt64) null.T[int64] {
+	return fromValid(i.Int64, i.Valid)
+}
+
+// ToSQLNullInt64 converts t to sql.NullInt64.
+func ToSQLNullInt64(t null.T[int64]) sql.NullInt64 {
+	return sql.NullInt64{Int64: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// FromSQLNullInt32 converts i to null.T[int32].
+func FromSQLNullInt32(i sql.NullInt32) null.T[int32] {
+	return fromValid(i.Int32, i.Valid)
+}
+
+// ToSQLNullInt32 converts t to sql.NullInt32.
+func ToSQLNullInt32(t null.T[int32]) sql.NullInt32 {
+	return sql.NullInt32{Int32: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// FromSQLNullInt16 converts i to null.T[int16].
+func FromSQLNullInt16(i sql.NullInt16) null.T[int16] {
+	return fromValid(i.Int16, i.Valid)
+}
+
+// ToSQLNullInt16 converts t to sql.NullInt16.
+func ToSQLNullInt16(t null.T[int16]) sql.NullInt16 {
+	return sql.NullInt16{Int16: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// FromSQLNullByte converts b to null.T[byte].
+func FromSQLNullByte(b sql.NullByte) null.T[byte] {
+	return fromValid(b.Byte, b.Valid)
+}
+
+// ToSQLNullByte converts t to sql.NullByte.
+func ToSQLNullByte(t null.T[byte]) sql.NullByte {
+	return sql.NullByte{Byte: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// FromSQLNullFloat64 converts f to null.T[float64].
+func FromSQLNullFloat64(f sql.NullFloat64) null.T[float64] {
+	return fromValid(f.Float64, f.Valid)
+}
+
+// ToSQLNullFloat64 converts t to sql.NullFloat64.
+func ToSQLNullFloat64(t null.T[float64]) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// FromSQLNullBool converts b to null.T[bool].
+func FromSQLNullBool(b sql.NullBool) null.T[bool] {
+	return fromValid(b.Bool, b.Valid)
+}
+
+// ToSQLNullBool converts t to sql.NullBool.
+func ToSQLNullBool(t null.T[bool]) sql.NullBool {
+	return sql.NullBool{Bool: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// FromSQLNullTime converts u to null.T[time.Time].
+func FromSQLNullTime(u sql.NullTime) null.T[time.Time] {
+	return fromValid(u.Time, u.Valid)
+}
+
+// ToSQLNullTime converts t to sql.NullTime.
+func ToSQLNullTime(t null.T[time.Time]) sql.NullTime {
+	return sql.NullTime{Time: t.ValueOrZero(), Valid: !t.IsNull()}
+}
+
+// ToPtr converts t to a pointer that is nil if t is null.
+// It is the counterpart of null.FromPtr and a function version of [null.T.Ptr],
+// so that it can be passed to Slice and Map.
+func ToPtr[V comparable](t null.T[V]) *V {
+	return t.Ptr()
+}
+
+// Slice returns a new slice holding f applied to each element of s, for example
+//
+//	names := convert.Slice(rows, convert.FromSQLNullString)
+//
+// It returns nil if s is nil.
+func Slice[S ~[]E, E, F any](s S, f func(E) F) []F {
+	if s == nil {
+		return nil
+	}
+	r := make([]F, len(s))
+	for i, e := range s {
+		r[i] = f(e)
+	}
+	return r
+}
+
+// Map returns a new map holding f applied to each value of m under the same key.
+// It returns nil if m is nil.
+func Map[M ~map[K]E, K comparable, E, F any](m M, f func(E) F) map[K]F {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]F, len(m))
+	for k, e := range m {
+		r[k] = f(e)
+	}
+	return r
+}
+
+func fromValid[V comparable](v V, valid bool) null.T[V] {
+	if !valid {
+		return null.T[V]{}
+	}
+	return null.From(v)
+}
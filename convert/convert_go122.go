@@ -0,0 +1,19 @@
+//go:build go1.22
+
+package convert
+
+import (
+	"database/sql"
+
+	"github.com/qawatake/null"
+)
+
+// FromSQLNull converts n to null.T[V].
+func FromSQLNull[V comparable](n sql.Null[V]) null.T[V] {
+	return fromValid(n.V, n.Valid)
+}
+
+// ToSQLNull converts t to sql.Null[V].
+func ToSQLNull[V comparable](t null.T[V]) sql.Null[V] {
+	return sql.Null[V]{V: t.ValueOrZero(), Valid: !t.IsNull()}
+}
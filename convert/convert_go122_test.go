@@ -0,0 +1,23 @@
+//go:build go1.22
+
+package convert_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/qawatake/null/convert"
+)
+
+func TestRoundTrip_Generic(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNull[int], convert.ToSQLNull[int], func(v int, valid bool) sql.Null[int] {
+			return sql.Null[int]{V: v, Valid: valid}
+		})
+	})
+	t.Run("array", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNull[[2]string], convert.ToSQLNull[[2]string], func(v [2]string, valid bool) sql.Null[[2]string] {
+			return sql.Null[[2]string]{V: v, Valid: valid}
+		})
+	})
+}
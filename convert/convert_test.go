@@ -0,0 +1,146 @@
+package convert_test
+
+import (
+	"database/sql"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/convert"
+	gnull "gopkg.in/guregu/null.v4"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNullString, convert.ToSQLNullString, func(v string, valid bool) sql.NullString {
+			return sql.NullString{String: v, Valid: valid}
+		})
+	})
+	t.Run("Int64", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNullInt64, convert.ToSQLNullInt64, func(v int64, valid bool) sql.NullInt64 {
+			return sql.NullInt64{Int64: v, Valid: valid}
+		})
+	})
+	t.Run("Int32", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNullInt32, convert.ToSQLNullInt32, func(v int32, valid bool) sql.NullInt32 {
+			return sql.NullInt32{Int32: v, Valid: valid}
+		})
+	})
+	t.Run("Int16", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNullInt16, convert.ToSQLNullInt16, func(v int16, valid bool) sql.NullInt16 {
+			return sql.NullInt16{Int16: v, Valid: valid}
+		})
+	})
+	t.Run("Byte", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNullByte, convert.ToSQLNullByte, func(v byte, valid bool) sql.NullByte {
+			return sql.NullByte{Byte: v, Valid: valid}
+		})
+	})
+	t.Run("Float64", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNullFloat64, convert.ToSQLNullFloat64, func(v float64, valid bool) sql.NullFloat64 {
+			return sql.NullFloat64{Float64: v, Valid: valid}
+		})
+	})
+	t.Run("Bool", func(t *testing.T) {
+		testRoundTrip(t, convert.FromSQLNullBool, convert.ToSQLNullBool, func(v bool, valid bool) sql.NullBool {
+			return sql.NullBool{Bool: v, Valid: valid}
+		})
+	})
+	t.Run("Time", func(t *testing.T) {
+		// testing/quick cannot generate time.Time, so generate it from Unix seconds.
+		testRoundTrip(t, convert.FromSQLNullTime, convert.ToSQLNullTime, func(sec int64, valid bool) sql.NullTime {
+			return sql.NullTime{Time: time.Unix(sec, 0), Valid: valid}
+		})
+	})
+	t.Run("Ptr", func(t *testing.T) {
+		f := func(v int, isNil bool) bool {
+			p := &v
+			if isNil {
+				p = nil
+			}
+			got := convert.ToPtr(null.FromPtr(p))
+			return cmp.Equal(got, p)
+		}
+		requireNoError(t, quick.Check(f, nil))
+	})
+}
+
+// testRoundTrip checks that from and to are inverse of each other,
+// except that from discards the payload of an invalid S.
+func testRoundTrip[V, G comparable, S comparable](t *testing.T, from func(S) null.T[V], to func(null.T[V]) S, gen func(G, bool) S) {
+	t.Helper()
+	f := func(g G, valid bool) bool {
+		s := gen(g, valid)
+		n := from(s)
+		if n.IsNull() == valid {
+			return false
+		}
+		if valid && to(n) != s {
+			return false
+		}
+		if !valid && to(n) != *new(S) {
+			return false
+		}
+		return from(to(n)) == n
+	}
+	requireNoError(t, quick.Check(f, nil))
+}
+
+func TestGuregu(t *testing.T) {
+	i := convert.FromSQLNullInt64(gnull.IntFrom(42).NullInt64)
+	assertEqual(t, i, null.From[int64](42))
+	assertEqual(t, gnull.Int{NullInt64: convert.ToSQLNullInt64(i)}, gnull.IntFrom(42))
+
+	s := convert.FromSQLNullString(gnull.String{}.NullString)
+	assertEqual(t, s.IsNull(), true)
+	assertEqual(t, gnull.String{NullString: convert.ToSQLNullString(s)}, gnull.String{})
+
+	f := convert.FromSQLNullFloat64(gnull.FloatFrom(1.5).NullFloat64)
+	assertEqual(t, gnull.Float{NullFloat64: convert.ToSQLNullFloat64(f)}, gnull.FloatFrom(1.5))
+
+	b := convert.FromSQLNullBool(gnull.BoolFrom(true).NullBool)
+	assertEqual(t, gnull.Bool{NullBool: convert.ToSQLNullBool(b)}, gnull.BoolFrom(true))
+
+	at := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tm := convert.FromSQLNullTime(gnull.TimeFrom(at).NullTime)
+	assertEqual(t, gnull.Time{NullTime: convert.ToSQLNullTime(tm)}, gnull.TimeFrom(at))
+}
+
+func TestSlice(t *testing.T) {
+	src := []sql.NullString{{String: "a", Valid: true}, {}, {String: "c", Valid: true}}
+	got := convert.Slice(src, convert.FromSQLNullString)
+	assertEqual(t, got, []null.T[string]{null.From("a"), {}, null.From("c")}, cmp.Comparer(null.T[string].Equal))
+	assertEqual(t, convert.Slice(got, convert.ToSQLNullString), src)
+	assertEqual(t, convert.Slice(got, convert.ToPtr[string]), []*string{toptr("a"), nil, toptr("c")})
+	assertEqual(t, convert.Slice([]sql.NullString(nil), convert.FromSQLNullString) == nil, true)
+}
+
+func TestMap(t *testing.T) {
+	src := map[string]*int64{"a": toptr[int64](1), "b": nil}
+	got := convert.Map(src, null.FromPtr[int64])
+	assertEqual(t, got, map[string]null.T[int64]{"a": null.From[int64](1), "b": {}}, cmp.Comparer(null.T[int64].Equal))
+	assertEqual(t, convert.Map(got, convert.ToSQLNullInt64), map[string]sql.NullInt64{"a": {Int64: 1, Valid: true}, "b": {}})
+	assertEqual(t, convert.Map(map[string]*int64(nil), null.FromPtr[int64]) == nil, true)
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T, opts ...cmp.Option) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y, opts...); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
+
+func toptr[T any](x T) *T {
+	return &x
+}
@@ -0,0 +1,461 @@
+// Package decimal provides a comparable arbitrary-precision decimal type
+// suitable as a payload of null.T for SQL DECIMAL and NUMERIC columns.
+//
+// Decimal implements the Decompose and Compose methods recognized by database/sql,
+// so drivers that produce decimals through those methods are scanned into null.T[decimal.Decimal]
+// without going through float64 or string.
+// Its Value method, and so that of null.T[decimal.Decimal], returns the text of the decimal,
+// which every driver accepts. A Decimal argument itself is passed to drivers as is,
+// as database/sql does for every type implementing Decompose,
+// so drivers supporting decimals consume it through Decompose.
+package decimal
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/qawatake/null"
+)
+
+// MaxExponent is the largest absolute exponent accepted when a Decimal is parsed, scanned or composed.
+// It bounds the size of the text representation of untrusted input.
+const MaxExponent = 6144
+
+// Decimal represents the exact decimal number coefficient × 10^exponent.
+// The zero value for Decimal is 0.
+//
+// Decimal is normalized on construction, so two Decimals represent the same number
+// if and only if they are equal in the sense of ==.
+// In particular, 1.5 and 1.50 are the same Decimal, and Decimal is strictly comparable.
+type Decimal struct {
+	neg bool
+	// coef holds the decimal digits of the coefficient without leading or trailing zeros.
+	// It is empty if the Decimal is 0.
+	coef string
+	exp  int32
+}
+
+// New returns the Decimal coef × 10^exp.
+func New(coef int64, exp int32) Decimal {
+	return fromBig(big.NewInt(coef), int64(exp))
+}
+
+// Parse parses a decimal number such as "-12.340" or "1.5e-3".
+// The syntax is that of a JSON number, except that a leading '+' and leading zeros are allowed.
+func Parse(s string) (Decimal, error) {
+	d, err := parse(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q: %w", s, err)
+	}
+	return d, nil
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+// It is intended for initializing variables and tests.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String returns d in plain notation without an exponent, such as "-12.34" or "1000".
+func (d Decimal) String() string {
+	if d.coef == "" {
+		return "0"
+	}
+	var b strings.Builder
+	if d.neg {
+		b.WriteByte('-')
+	}
+	switch k := -int(d.exp); {
+	case k <= 0:
+		b.WriteString(d.coef)
+		b.WriteString(strings.Repeat("0", -k))
+	case k < len(d.coef):
+		b.WriteString(d.coef[:len(d.coef)-k])
+		b.WriteByte('.')
+		b.WriteString(d.coef[len(d.coef)-k:])
+	default:
+		b.WriteString("0.")
+		b.WriteString(strings.Repeat("0", k-len(d.coef)))
+		b.WriteString(d.coef)
+	}
+	return b.String()
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and +1 if d > 0.
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef == "":
+		return 0
+	case d.neg:
+		return -1
+	}
+	return +1
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.coef == ""
+}
+
+// Cmp compares d and e. It returns -1 if d < e, 0 if d == e and +1 if d > e.
+func (d Decimal) Cmp(e Decimal) int {
+	if ds, es := d.Sign(), e.Sign(); ds != es || ds == 0 {
+		return cmpInt(ds, es)
+	}
+	c := cmpAbs(d, e)
+	if d.neg {
+		return -c
+	}
+	return c
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	if d.coef != "" {
+		d.neg = !d.neg
+	}
+	return d
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+	d.neg = false
+	return d
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	x, y, exp := align(d, e)
+	return fromBig(x.Add(x, y), exp)
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	return d.Add(e.Neg())
+}
+
+// Mul returns d × e.
+func (d Decimal) Mul(e Decimal) Decimal {
+	x, y := d.bigCoef(), e.bigCoef()
+	return fromBig(x.Mul(x, y), int64(d.exp)+int64(e.exp))
+}
+
+// Quo returns d ÷ e rounded half away from zero to places digits after the decimal point.
+// places may be negative to round to tens, hundreds and so on.
+// It returns an error if e is 0.
+func (d Decimal) Quo(e Decimal, places int32) (Decimal, error) {
+	if e.IsZero() {
+		return Decimal{}, errors.New("decimal: division by zero")
+	}
+	num, den := d.bigCoef(), e.bigCoef()
+	if k := int64(d.exp) - int64(e.exp) + int64(places); k >= 0 {
+		num.Mul(num, pow10(k))
+	} else {
+		den.Mul(den, pow10(-k))
+	}
+	return fromBig(quoRound(num, den), -int64(places)), nil
+}
+
+// Round returns d rounded half away from zero to places digits after the decimal point.
+// places may be negative to round to tens, hundreds and so on.
+func (d Decimal) Round(places int32) Decimal {
+	k := -int64(d.exp) - int64(places)
+	if k <= 0 {
+		return d
+	}
+	return fromBig(quoRound(d.bigCoef(), pow10(k)), -int64(places))
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Decompose returns the internal decimal state in parts.
+// It implements the decimal interface recognized by database/sql.
+// If buf has sufficient capacity, buf may be returned as the coefficient.
+func (d Decimal) Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32) {
+	c := d.bigCoef()
+	return 0, d.neg, append(buf[:0], c.Abs(c).Bytes()...), d.exp
+}
+
+// Compose sets d from its parts.
+// It implements the decimal interface recognized by database/sql.
+// Infinite and NaN forms are not supported.
+func (d *Decimal) Compose(form byte, negative bool, coefficient []byte, exponent int32) error {
+	if form != 0 {
+		return fmt.Errorf("decimal: unsupported form %d", form)
+	}
+	c := new(big.Int).SetBytes(coefficient)
+	if negative {
+		c.Neg(c)
+	}
+	v := fromBig(c, int64(exponent))
+	if err := v.checkExponent(); err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+var _ sql.Scanner = &Decimal{}
+
+// Scan implements the sql.Scanner interface.
+// It accepts decimal text, int64, float64 and values implementing Decompose.
+// A float64 is converted through its shortest decimal representation.
+func (d *Decimal) Scan(src any) error {
+	switch s := src.(type) {
+	case string:
+		return d.scanText(s)
+	case []byte:
+		return d.scanText(string(s))
+	case int64:
+		*d = New(s, 0)
+		return nil
+	case float64:
+		if math.IsInf(s, 0) || math.IsNaN(s) {
+			return fmt.Errorf("decimal: cannot convert %v to Decimal", s)
+		}
+		return d.scanText(strconv.FormatFloat(s, 'g', -1, 64))
+	case decomposer:
+		return d.Compose(s.Decompose(nil))
+	}
+	return fmt.Errorf("decimal: unsupported Scan, storing driver.Value type %T into type %T", src, d)
+}
+
+var _ driver.Valuer = Decimal{}
+
+// Value implements the driver.Valuer interface.
+// It returns the result of d.String(), since most drivers reject other types of values.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+var _ json.Marshaler = Decimal{}
+
+// MarshalJSON implements the json.Marshaler interface.
+// d is encoded as an exact JSON number in plain notation.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+var _ json.Unmarshaler = &Decimal{}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts a JSON number or a JSON string containing a decimal number, and decodes it without loss of precision.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.scanText(s)
+	}
+	if bytes.Equal(data, []byte("null")) {
+		// By convention, unmarshaling null into a non-nullable value is a no-op.
+		// Use null.T[Decimal] to distinguish null from a number.
+		return nil
+	}
+	return d.scanText(string(data))
+}
+
+// Add returns x + y, or null if either x or y is null.
+func Add(x, y null.T[Decimal]) null.T[Decimal] {
+	if x.IsNull() || y.IsNull() {
+		return null.T[Decimal]{}
+	}
+	return null.From(x.ValueOrZero().Add(y.ValueOrZero()))
+}
+
+// Sub returns x - y, or null if either x or y is null.
+func Sub(x, y null.T[Decimal]) null.T[Decimal] {
+	if x.IsNull() || y.IsNull() {
+		return null.T[Decimal]{}
+	}
+	return null.From(x.ValueOrZero().Sub(y.ValueOrZero()))
+}
+
+// Mul returns x × y, or null if either x or y is null.
+func Mul(x, y null.T[Decimal]) null.T[Decimal] {
+	if x.IsNull() || y.IsNull() {
+		return null.T[Decimal]{}
+	}
+	return null.From(x.ValueOrZero().Mul(y.ValueOrZero()))
+}
+
+// Quo returns x ÷ y rounded as [Decimal.Quo] does, or null if either x or y is null.
+// It returns an error if y is 0.
+func Quo(x, y null.T[Decimal], places int32) (null.T[Decimal], error) {
+	if x.IsNull() || y.IsNull() {
+		return null.T[Decimal]{}, nil
+	}
+	q, err := x.ValueOrZero().Quo(y.ValueOrZero(), places)
+	if err != nil {
+		return null.T[Decimal]{}, err
+	}
+	return null.From(q), nil
+}
+
+type decomposer interface {
+	Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32)
+}
+
+func (d *Decimal) scanText(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func (d Decimal) checkExponent() error {
+	if d.exp > MaxExponent || d.exp < -MaxExponent {
+		return fmt.Errorf("decimal: exponent %d out of range [-%d, %d]", d.exp, MaxExponent, MaxExponent)
+	}
+	return nil
+}
+
+func (d Decimal) bigCoef() *big.Int {
+	c := new(big.Int)
+	if d.coef != "" {
+		c.SetString(d.coef, 10)
+	}
+	if d.neg {
+		c.Neg(c)
+	}
+	return c
+}
+
+func parse(s string) (Decimal, error) {
+	rest := s
+	var neg bool
+	if rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		neg = rest[0] == '-'
+		rest = rest[1:]
+	}
+	intPart, rest := leadingDigits(rest)
+	var fracPart string
+	if rest != "" && rest[0] == '.' {
+		fracPart, rest = leadingDigits(rest[1:])
+		if fracPart == "" {
+			return Decimal{}, errors.New("missing digits after decimal point")
+		}
+	}
+	if intPart == "" {
+		return Decimal{}, errors.New("missing digits before decimal point")
+	}
+	var exp int64
+	if rest != "" && (rest[0] == 'e' || rest[0] == 'E') {
+		e, err := strconv.ParseInt(rest[1:], 10, 32)
+		if err != nil {
+			return Decimal{}, errors.New("invalid exponent")
+		}
+		exp, rest = e, ""
+	}
+	if rest != "" {
+		return Decimal{}, fmt.Errorf("unexpected %q", rest)
+	}
+	coef := strings.TrimLeft(intPart+fracPart, "0")
+	exp -= int64(len(fracPart))
+	trimmed := strings.TrimRight(coef, "0")
+	exp += int64(len(coef) - len(trimmed))
+	if trimmed == "" {
+		return Decimal{}, nil
+	}
+	if exp > MaxExponent || exp < -MaxExponent {
+		return Decimal{}, fmt.Errorf("exponent out of range [-%d, %d]", MaxExponent, MaxExponent)
+	}
+	return Decimal{neg: neg, coef: trimmed, exp: int32(exp)}, nil
+}
+
+func leadingDigits(s string) (digits, rest string) {
+	i := 0
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// fromBig returns the normalized Decimal c × 10^exp.
+// It panics if the exponent of the result overflows int32.
+func fromBig(c *big.Int, exp int64) Decimal {
+	if c.Sign() == 0 {
+		return Decimal{}
+	}
+	s := new(big.Int).Abs(c).Text(10)
+	trimmed := strings.TrimRight(s, "0")
+	exp += int64(len(s) - len(trimmed))
+	if exp > math.MaxInt32 || exp < math.MinInt32 {
+		panic("decimal: exponent overflow")
+	}
+	return Decimal{neg: c.Sign() < 0, coef: trimmed, exp: int32(exp)}
+}
+
+// align returns the coefficients of d and e scaled to their common exponent.
+func align(d, e Decimal) (x, y *big.Int, exp int64) {
+	x, y = d.bigCoef(), e.bigCoef()
+	switch {
+	case d.exp > e.exp:
+		x.Mul(x, pow10(int64(d.exp)-int64(e.exp)))
+		return x, y, int64(e.exp)
+	case d.exp < e.exp:
+		y.Mul(y, pow10(int64(e.exp)-int64(d.exp)))
+	}
+	return x, y, int64(d.exp)
+}
+
+// quoRound returns num ÷ den rounded half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	neg := num.Sign()*den.Sign() < 0
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(num), new(big.Int).Abs(den), new(big.Int))
+	if r.Lsh(r, 1).CmpAbs(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if neg {
+		q.Neg(q)
+	}
+	return q
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// cmpAbs compares |d| and |e|, both of which must be non-zero.
+func cmpAbs(d, e Decimal) int {
+	// The adjusted exponent is the position of the most significant digit.
+	if c := cmpInt(len(d.coef)+int(d.exp), len(e.coef)+int(e.exp)); c != 0 {
+		return c
+	}
+	x, y := d.coef, e.coef
+	if n := len(x) - len(y); n > 0 {
+		y += strings.Repeat("0", n)
+	} else {
+		x += strings.Repeat("0", -n)
+	}
+	return strings.Compare(x, y)
+}
+
+func cmpInt(x, y int) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return +1
+	}
+	return 0
+}
@@ -0,0 +1,249 @@
+package decimal_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/decimal"
+	"github.com/qawatake/null/internal/fakedb"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "0", want: "0"},
+		{in: "-0.000", want: "0"},
+		{in: "12.340", want: "12.34"},
+		{in: "+007", want: "7"},
+		{in: "-1.5e-3", want: "-0.0015"},
+		{in: "1.5E3", want: "1500"},
+		{in: "123456789012345678901234567890.123456789", want: "123456789012345678901234567890.123456789"},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".5", wantErr: true},
+		{in: "5.", wantErr: true},
+		{in: "1e", wantErr: true},
+		{in: "1e+", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "NaN", wantErr: true},
+		{in: "1e6145", wantErr: true},
+		{in: "1e99999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := decimal.Parse(tt.in)
+			assertEqual(t, err != nil, tt.wantErr)
+			if err == nil {
+				assertEqual(t, d.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestComparable(t *testing.T) {
+	assertEqual(t, decimal.MustParse("1.50") == decimal.MustParse("1.5"), true)
+	assertEqual(t, decimal.New(150, -2) == decimal.New(15, -1), true)
+	assertEqual(t, decimal.New(0, 10) == decimal.Decimal{}, true)
+	assertEqual(t, null.From(decimal.MustParse("1.5")) == null.From(decimal.MustParse("1.500")), true)
+}
+
+func TestArithmetic(t *testing.T) {
+	x := decimal.MustParse("10.25")
+	y := decimal.MustParse("-0.4")
+
+	assertEqual(t, x.Add(y).String(), "9.85")
+	assertEqual(t, x.Sub(y).String(), "10.65")
+	assertEqual(t, x.Mul(y).String(), "-4.1")
+	assertEqual(t, x.Neg().String(), "-10.25")
+	assertEqual(t, y.Abs().String(), "0.4")
+	assertEqual(t, x.Cmp(y), 1)
+	assertEqual(t, y.Cmp(x), -1)
+	assertEqual(t, x.Cmp(decimal.New(1025, -2)), 0)
+	assertEqual(t, decimal.MustParse("0.1").Add(decimal.MustParse("0.2")) == decimal.MustParse("0.3"), true)
+
+	q, err := x.Quo(y, 2)
+	requireNoError(t, err)
+	assertEqual(t, q.String(), "-25.63")
+
+	q, err = decimal.New(1, 0).Quo(decimal.New(3, 0), 5)
+	requireNoError(t, err)
+	assertEqual(t, q.String(), "0.33333")
+
+	_, err = x.Quo(decimal.Decimal{}, 2)
+	requireError(t, err)
+
+	assertEqual(t, x.Round(1).String(), "10.3")
+	assertEqual(t, x.Neg().Round(1).String(), "-10.3")
+	assertEqual(t, x.Round(-1).String(), "10")
+	assertEqual(t, x.Round(5).String(), "10.25")
+}
+
+func TestNullArithmetic(t *testing.T) {
+	x := null.From(decimal.MustParse("1.25"))
+	y := null.From(decimal.MustParse("2"))
+	var n null.T[decimal.Decimal]
+
+	assertEqual(t, decimal.Add(x, y) == null.From(decimal.MustParse("3.25")), true)
+	assertEqual(t, decimal.Sub(x, y) == null.From(decimal.MustParse("-0.75")), true)
+	assertEqual(t, decimal.Mul(x, y) == null.From(decimal.MustParse("2.5")), true)
+	assertEqual(t, decimal.Add(x, n).IsNull(), true)
+	assertEqual(t, decimal.Sub(n, y).IsNull(), true)
+	assertEqual(t, decimal.Mul(n, n).IsNull(), true)
+
+	q, err := decimal.Quo(x, y, 1)
+	requireNoError(t, err)
+	assertEqual(t, q == null.From(decimal.MustParse("0.6")), true)
+
+	q, err = decimal.Quo(n, null.From(decimal.Decimal{}), 1)
+	requireNoError(t, err)
+	assertEqual(t, q.IsNull(), true)
+
+	_, err = decimal.Quo(x, null.From(decimal.Decimal{}), 1)
+	requireError(t, err)
+}
+
+// driverDecimal is a decimal type of a hypothetical driver which only implements Decompose.
+type driverDecimal struct {
+	neg  bool
+	coef []byte
+	exp  int32
+}
+
+func (d driverDecimal) Decompose(buf []byte) (byte, bool, []byte, int32) {
+	return 0, d.neg, d.coef, d.exp
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    null.T[decimal.Decimal]
+		wantErr bool
+	}{
+		{name: "nil", src: nil},
+		{name: "string", src: "12.340", want: null.From(decimal.New(1234, -2))},
+		{name: "[]byte", src: []byte("-0.5"), want: null.From(decimal.New(-5, -1))},
+		{name: "int64", src: int64(42), want: null.From(decimal.New(42, 0))},
+		{name: "float64", src: 0.1, want: null.From(decimal.New(1, -1))},
+		{name: "decompose", src: driverDecimal{neg: true, coef: []byte{0x01, 0x00}, exp: -2}, want: null.From(decimal.New(-256, -2))},
+		{name: "invalid string", src: "abc", wantErr: true},
+		{name: "bool", src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got null.T[decimal.Decimal]
+			err := got.Scan(tt.src)
+			assertEqual(t, err != nil, tt.wantErr)
+			assertEqual(t, got == tt.want, true)
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	v, err := decimal.MustParse("-12.30").Value()
+	requireNoError(t, err)
+	assertEqual(t, v, any("-12.3"))
+
+	v, err = null.From(decimal.MustParse("-12.30")).Value()
+	requireNoError(t, err)
+	assertEqual(t, v, any("-12.3"))
+
+	v, err = null.T[decimal.Decimal]{}.Value()
+	requireNoError(t, err)
+	assertEqual(t, v, nil)
+}
+
+// decomposer is the interface through which database/sql recognizes decimals.
+type decomposer interface {
+	Decompose(buf []byte) (form byte, negative bool, coefficient []byte, exponent int32)
+}
+
+func TestValue_Driver(t *testing.T) {
+	d := decimal.MustParse("1.50")
+	var fake fakedb.DB
+	rows, err := fake.Open().Query("SELECT", null.From(d), d)
+	requireNoError(t, err)
+	requireNoError(t, rows.Close())
+
+	args := fake.Args()
+	assertEqual(t, args[0].Value, driver.Value("1.5"))
+	got, ok := args[1].Value.(decomposer)
+	if !ok {
+		t.Fatalf("want a decomposer, but the driver received %T", args[1].Value)
+	}
+	_, negative, coefficient, exponent := got.Decompose(nil)
+	assertEqual(t, negative, false)
+	assertEqual(t, coefficient, []byte{15})
+	assertEqual(t, exponent, int32(-1))
+}
+
+func TestDecomposeCompose(t *testing.T) {
+	d := decimal.MustParse("-123456789012345678901234567890.5")
+	form, neg, coef, exp := d.Decompose(make([]byte, 0, 32))
+	assertEqual(t, form, byte(0))
+	assertEqual(t, neg, true)
+	assertEqual(t, exp, int32(-1))
+
+	var got decimal.Decimal
+	requireNoError(t, got.Compose(form, neg, coef, exp))
+	assertEqual(t, got == d, true)
+
+	requireError(t, got.Compose(2, false, nil, 0))
+	requireError(t, got.Compose(0, false, []byte{1}, decimal.MaxExponent+1))
+}
+
+func TestJSON(t *testing.T) {
+	type Payment struct {
+		Amount null.T[decimal.Decimal]
+		Fee    null.T[decimal.Decimal]
+	}
+
+	data := []byte(`{"Amount":12345678901234567890.123456789,"Fee":null}`)
+	var p Payment
+	requireNoError(t, json.Unmarshal(data, &p))
+	assertEqual(t, p.Amount == null.From(decimal.MustParse("12345678901234567890.123456789")), true)
+	assertEqual(t, p.Fee.IsNull(), true)
+
+	got, err := json.Marshal(p)
+	requireNoError(t, err)
+	assertEqual(t, string(got), string(data))
+
+	requireNoError(t, json.Unmarshal([]byte(`{"Amount":"1.5"}`), &p))
+	assertEqual(t, p.Amount == null.From(decimal.MustParse("1.5")), true)
+
+	requireError(t, json.Unmarshal([]byte(`{"Amount":true}`), &p))
+	requireError(t, json.Unmarshal([]byte(`{"Amount":1e9999}`), &p))
+}
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/decimal"
+)
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"0", "-12.340", "1.5e-3", "123456789012345678901234567890.5", "1e6144"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		d, err := decimal.Parse(in)
+		if err != nil {
+			return
+		}
+		want, ok := new(big.Rat).SetString(in)
+		if !ok {
+			t.Fatalf("in: %q, parsed by decimal but not by big.Rat", in)
+		}
+		if got := rat(t, d); got.Cmp(want) != 0 {
+			t.Errorf("in: %q, got %v, want %v", in, got, want)
+		}
+
+		// String round-trips exactly.
+		d2, err := decimal.Parse(d.String())
+		if err != nil {
+			t.Fatalf("in: %q, String: %q, err: %v", in, d.String(), err)
+		}
+		if d2 != d {
+			t.Errorf("in: %q, String: %q does not round-trip", in, d.String())
+		}
+
+		// Decompose and Compose round-trip exactly.
+		var d3 decimal.Decimal
+		if err := d3.Compose(d.Decompose(nil)); err != nil {
+			t.Fatalf("in: %q, err: %v", in, err)
+		}
+		if d3 != d {
+			t.Errorf("in: %q, Compose(Decompose()) = %v", in, d3)
+		}
+
+		// JSON round-trips exactly.
+		data, err := json.Marshal(null.From(d))
+		if err != nil {
+			t.Fatalf("in: %q, err: %v", in, err)
+		}
+		var n null.T[decimal.Decimal]
+		if err := json.Unmarshal(data, &n); err != nil {
+			t.Fatalf("in: %q, data: %s, err: %v", in, data, err)
+		}
+		if n != null.From(d) {
+			t.Errorf("in: %q, data: %s does not round-trip", in, data)
+		}
+	})
+}
+
+func FuzzArithmetic(f *testing.F) {
+	f.Add("10.25", "-0.4", int8(2))
+	f.Add("1", "3", int8(5))
+	f.Add("0.1", "0.2", int8(-1))
+	f.Fuzz(func(t *testing.T, a, b string, places int8) {
+		x, err := decimal.Parse(a)
+		if err != nil || len(a) > 64 {
+			return
+		}
+		y, err := decimal.Parse(b)
+		if err != nil || len(b) > 64 {
+			return
+		}
+		rx, ry := rat(t, x), rat(t, y)
+
+		if got, want := rat(t, x.Add(y)), new(big.Rat).Add(rx, ry); got.Cmp(want) != 0 {
+			t.Errorf("%v + %v = %v, want %v", x, y, got, want)
+		}
+		if got, want := rat(t, x.Sub(y)), new(big.Rat).Sub(rx, ry); got.Cmp(want) != 0 {
+			t.Errorf("%v - %v = %v, want %v", x, y, got, want)
+		}
+		if got, want := rat(t, x.Mul(y)), new(big.Rat).Mul(rx, ry); got.Cmp(want) != 0 {
+			t.Errorf("%v * %v = %v, want %v", x, y, got, want)
+		}
+		if got, want := x.Cmp(y), rx.Cmp(ry); got != want {
+			t.Errorf("%v cmp %v = %v, want %v", x, y, got, want)
+		}
+
+		if y.IsZero() {
+			return
+		}
+		q, err := x.Quo(y, int32(places))
+		if err != nil {
+			t.Fatalf("%v / %v: %v", x, y, err)
+		}
+		// |q - x/y| <= 10^-places / 2
+		diff := new(big.Rat).Sub(rat(t, q), new(big.Rat).Quo(rx, ry))
+		half := rat(t, decimal.New(5, -int32(places)-1))
+		if diff.Abs(diff).Cmp(half) > 0 {
+			t.Errorf("%v / %v = %v, off by %v", x, y, q, diff)
+		}
+	})
+}
+
+func rat(t *testing.T, d decimal.Decimal) *big.Rat {
+	t.Helper()
+	r, ok := new(big.Rat).SetString(d.String())
+	if !ok {
+		t.Fatalf("big.Rat cannot parse %q", d.String())
+	}
+	return r
+}
@@ -3,6 +3,11 @@ package null_test
 import (
 	"encoding/json"
 	"fmt"
+	"hash/maphash"
+	"log/slog"
+	"os"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/qawatake/null"
@@ -150,3 +155,138 @@ func ExampleT_Ptr() {
 	// Output:
 	// p1 != p2: true
 }
+
+func ExampleSensitive() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	name := null.From("alice")
+	email := null.Sensitive[string]{T: null.From("alice@example.com")}
+	var phone null.Sensitive[string]
+	logger.Info("signup", "name", name, "email", email, "phone", phone)
+	// Output:
+	// level=INFO msg=signup name=alice email=[set] phone=<nil>
+}
+
+func ExampleT_Format() {
+	fmt.Printf("%v %v\n", null.From(3), null.T[int]{})
+	fmt.Printf("%.2f %q\n", null.From(3.14159), null.From("a"))
+	fmt.Printf("%#v %#v\n", null.From(3), null.T[int]{})
+	// Output:
+	// 3 null
+	// 3.14 "a"
+	// null.From[int](3) null.T[int]{}
+}
+
+func ExampleT_Get() {
+	for _, t := range []null.T[int]{null.From(3), {}} {
+		if v, ok := t.Get(); ok {
+			fmt.Println("value:", v)
+		} else {
+			fmt.Println("null")
+		}
+	}
+	// Output:
+	// value: 3
+	// null
+}
+
+func ExampleT_Hash() {
+	seed := maphash.MakeSeed()
+	sum := func(t null.T[time.Time]) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		t.Hash(&h)
+		return h.Sum64()
+	}
+
+	utc := null.From(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	jst := null.From(utc.ValueOrZero().In(time.FixedZone("JST", 9*60*60)))
+	fmt.Println(utc == jst, utc.Equal(jst), sum(utc) == sum(jst))
+	// Output:
+	// false true true
+}
+
+func ExampleAtomic() {
+	// The exit code of the last finished job, shared between goroutines.
+	var lastExitCode null.Atomic[int32]
+	fmt.Println(lastExitCode.Load())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		lastExitCode.Store(null.From[int32](1))
+	}()
+	wg.Wait()
+	fmt.Println(lastExitCode.Load())
+
+	fmt.Println(lastExitCode.CompareAndSwap(null.From[int32](1), null.From[int32](0)), lastExitCode.Load())
+	lastExitCode.Clear()
+	fmt.Println(lastExitCode.Load())
+	// Output:
+	// null
+	// 1
+	// true 0
+	// null
+}
+
+func ExampleFromAny() {
+	// Set the fields of a struct from payloads of type any, as a decoder driven by reflection would do.
+	var user struct {
+		Name null.T[string]
+		Age  null.T[int]
+	}
+	rv := reflect.ValueOf(&user).Elem()
+	for i, payload := range []any{"alice", nil} {
+		n, err := null.FromAny(rv.Field(i).Type(), payload)
+		if err != nil {
+			panic(err)
+		}
+		rv.Field(i).Set(reflect.ValueOf(n))
+	}
+	fmt.Println(user.Name, user.Age)
+
+	// Inspect them without knowing their payload types.
+	for i := 0; i < rv.NumField(); i++ {
+		n := rv.Field(i).Interface().(null.Nullable)
+		fmt.Println(n.PayloadType(), n.IsNull(), n.Any())
+	}
+	// Output:
+	// alice null
+	// string false alice
+	// int true <nil>
+}
+
+func ExampleCoalesce() {
+	type DB struct {
+		Host null.T[string]
+		Port null.T[int]
+	}
+	type Config struct {
+		Verbose null.T[bool]
+		DB      DB
+	}
+
+	flags := Config{Verbose: null.From(true)}
+	env := Config{DB: DB{Host: null.From("db.internal")}}
+	defaults := Config{Verbose: null.From(false), DB: DB{Host: null.From("localhost"), Port: null.From(5432)}}
+
+	cfg, from := null.Coalesce(flags, env, defaults)
+	fmt.Println(cfg.Verbose, cfg.DB.Host, cfg.DB.Port)
+	layers := []string{"flags", "env", "defaults"}
+	for _, path := range []string{"Verbose", "DB.Host", "DB.Port"} {
+		fmt.Println(path, "from", layers[from[path]])
+	}
+	// Output:
+	// true db.internal 5432
+	// Verbose from flags
+	// DB.Host from env
+	// DB.Port from defaults
+}
@@ -0,0 +1,79 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// NullString is printed by the Format method for a null T, except with the %#v verb.
+// It is "null" by default, the JSON literal. It should be set only during program initialization.
+var NullString = "null"
+
+var (
+	_ fmt.Formatter  = T[int]{}
+	_ fmt.GoStringer = T[int]{}
+)
+
+// Format implements the fmt.Formatter interface.
+// The verb, flags, width and precision are applied to the payload, so null.From(3.14159) is printed as 3.14 by %.2f.
+// A null T is printed as [NullString], padded to the width.
+// The %#v verb prints the Go syntax returned by [T.GoString].
+func (t T[V]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, t.GoString())
+		return
+	}
+	if t.IsNull() {
+		formatPadded(f, NullString)
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), t.v.V)
+}
+
+// GoString implements the fmt.GoStringer interface.
+// It returns null.From[V](v) with v in Go syntax if t is not null, and null.T[V]{} otherwise.
+func (t T[V]) GoString() string {
+	name := reflect.TypeOf((*V)(nil)).Elem().String()
+	if t.IsNull() {
+		return "null.T[" + name + "]{}"
+	}
+	return fmt.Sprintf("null.From[%s](%#v)", name, t.v.V)
+}
+
+var (
+	_ fmt.Formatter  = Sensitive[int]{}
+	_ fmt.GoStringer = Sensitive[int]{}
+)
+
+// Format implements the fmt.Formatter interface.
+// Like LogValue, it prints "[set]" if s is not null and [NullString] otherwise, padded to the width, for every verb.
+func (s Sensitive[V]) Format(f fmt.State, verb rune) {
+	if s.IsNull() {
+		formatPadded(f, NullString)
+		return
+	}
+	formatPadded(f, redacted)
+}
+
+// GoString implements the fmt.GoStringer interface, in place of the method of T, which would print the payload.
+// It returns null.Sensitive[V]{[set]} if s is not null, and null.Sensitive[V]{} otherwise.
+func (s Sensitive[V]) GoString() string {
+	name := reflect.TypeOf((*V)(nil)).Elem().String()
+	if s.IsNull() {
+		return "null.Sensitive[" + name + "]{}"
+	}
+	return "null.Sensitive[" + name + "]{" + redacted + "}"
+}
+
+// formatPadded writes s padded to the width of f, ignoring the verb and the precision.
+func formatPadded(f fmt.State, s string) {
+	format := "%"
+	if f.Flag('-') {
+		format += "-"
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	fmt.Fprintf(f, format+"s", s)
+}
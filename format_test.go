@@ -0,0 +1,85 @@
+package null_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type point struct{ X, Y int }
+
+type stringer struct{ s string }
+
+func (s stringer) String() string { return "<" + s.s + ">" }
+
+// TestFormat prints values by each verb and compares the output with testdata/format.golden.
+// Run go test -run TestFormat -update to regenerate it.
+func TestFormat(t *testing.T) {
+	values := []struct {
+		name  string
+		value any
+	}{
+		{name: "int", value: null.From(42)},
+		{name: "null int", value: null.T[int]{}},
+		{name: "float", value: null.From(3.14159)},
+		{name: "null float", value: null.T[float64]{}},
+		{name: "string", value: null.From(`a "b"`)},
+		{name: "empty string", value: null.From("")},
+		{name: "null string", value: null.T[string]{}},
+		{name: "bool", value: null.From(true)},
+		{name: "array", value: null.From([3]int{1, 2, 3})},
+		{name: "struct", value: null.From(point{X: 1, Y: 2})},
+		{name: "null struct", value: null.T[point]{}},
+		{name: "Stringer", value: null.From(stringer{s: "x"})},
+		{name: "Duration", value: null.From(1500 * time.Millisecond)},
+		{name: "time", value: null.From(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))},
+		{name: "any", value: null.From[any](1)},
+		{name: "null any", value: null.T[any]{}},
+		{name: "sensitive", value: null.Sensitive[string]{T: null.From("alice")}},
+		{name: "null sensitive", value: null.Sensitive[string]{}},
+	}
+	verbs := []string{"%v", "%+v", "%#v", "%s", "%q", "%d", "%x", "%.2f", "%6v", "%-6v|", "%t"}
+
+	var buf bytes.Buffer
+	for _, v := range values {
+		fmt.Fprintf(&buf, "# %s\n", v.name)
+		for _, verb := range verbs {
+			fmt.Fprintf(&buf, "%-6s %s\n", verb, fmt.Sprintf(verb, v.value))
+		}
+		fmt.Fprintf(&buf, "Sprint %s\n", fmt.Sprint(v.value))
+		fmt.Fprintf(&buf, "nested %v\n", struct{ V any }{v.value})
+		buf.WriteByte('\n')
+	}
+	got := buf.Bytes()
+
+	golden := filepath.Join("testdata", "format.golden")
+	if *update {
+		requireNoError(t, os.WriteFile(golden, got, 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	requireNoError(t, err)
+	assertEqual(t, string(got), string(want))
+}
+
+func TestFormat_NullString(t *testing.T) {
+	old := null.NullString
+	t.Cleanup(func() { null.NullString = old })
+	null.NullString = "<nil>"
+
+	assertEqual(t, fmt.Sprintf("%v|%5d|%#v", null.T[int]{}, null.T[int]{}, null.T[int]{}), "<nil>|<nil>|null.T[int]{}")
+	assertEqual(t, fmt.Sprint(null.Sensitive[int]{}), "<nil>")
+}
+
+func TestSensitive_GoString(t *testing.T) {
+	// The GoString method of T, which prints the payload, is not promoted.
+	assertEqual(t, null.Sensitive[string]{T: null.From("alice")}.GoString(), "null.Sensitive[string]{[set]}")
+	assertEqual(t, null.Sensitive[string]{}.GoString(), "null.Sensitive[string]{}")
+}
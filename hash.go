@@ -0,0 +1,145 @@
+package null
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"time"
+)
+
+// hasher is implemented by types which write a hash consistent with their Equal method, such as T.
+type hasher interface {
+	Hash(h *maphash.Hash)
+}
+
+var _ hasher = T[int]{}
+
+// Hash writes t to h, so that T can be used in hash-based containers:
+//
+//	var h maphash.Hash
+//	h.SetSeed(seed)
+//	t.Hash(&h)
+//	sum := h.Sum64()
+//
+// The hash is consistent with [T.Equal]: if t.Equal(u), t and u write the same bytes.
+// A null T writes a byte different from that written first by a non-null T.
+// A payload of type time.Time is hashed as the instant it represents, regardless of its location and monotonic clock reading.
+// A payload, or a field or element of it, with a method Hash(*maphash.Hash) is hashed by the method.
+// Other payloads are hashed by their representation, so if V has an Equal method
+// other than that of time.Time, it should also have a consistent Hash method.
+func (t T[V]) Hash(h *maphash.Hash) {
+	if t.IsNull() {
+		h.WriteByte(0)
+		return
+	}
+	h.WriteByte(1)
+	// Switching on a pointer avoids converting the payload to an interface, which allocates.
+	switch p := any(&t.v.V).(type) {
+	case *int:
+		writeUint64(h, uint64(*p))
+	case *int64:
+		writeUint64(h, uint64(*p))
+	case *string:
+		writeString(h, *p)
+	case *bool:
+		writeBool(h, *p)
+	case *time.Time:
+		writeTime(h, *p)
+	default:
+		v := t.v.V
+		writeValue(h, reflect.ValueOf(&v).Elem())
+	}
+}
+
+var hasherType = reflect.TypeOf((*hasher)(nil)).Elem()
+
+// writeValue writes v to h such that values equal in the sense of == write the same bytes.
+func writeValue(h *maphash.Hash, v reflect.Value) {
+	t := v.Type()
+	if v.CanInterface() {
+		if t.Implements(hasherType) {
+			v.Interface().(hasher).Hash(h)
+			return
+		}
+		if t == timeType {
+			writeTime(h, v.Interface().(time.Time))
+			return
+		}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		writeBool(h, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(h, real(c))
+		writeFloat(h, imag(c))
+	case reflect.String:
+		writeString(h, v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+		h.WriteByte(1)
+		e := v.Elem()
+		writeString(h, e.Type().String())
+		writeValue(h, e)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).Name == "_" {
+				// Blank fields are ignored by ==.
+				continue
+			}
+			writeValue(h, v.Field(i))
+		}
+	default:
+		// Not comparable, which is not the case for a field or element of a comparable type.
+		panic("null: cannot hash a value of type " + t.String())
+	}
+}
+
+func writeUint64(h *maphash.Hash, x uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	h.Write(b[:])
+}
+
+func writeBool(h *maphash.Hash, b bool) {
+	if b {
+		h.WriteByte(1)
+	} else {
+		h.WriteByte(0)
+	}
+}
+
+// writeFloat writes f such that 0 and -0, which are equal, write the same bytes.
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0
+	}
+	writeUint64(h, math.Float64bits(f))
+}
+
+// writeString writes s prefixed by its length, so that consecutive strings are not confused.
+func writeString(h *maphash.Hash, s string) {
+	writeUint64(h, uint64(len(s)))
+	h.WriteString(s)
+}
+
+func writeTime(h *maphash.Hash, t time.Time) {
+	writeUint64(h, uint64(t.Unix()))
+	writeUint64(h, uint64(t.Nanosecond()))
+}
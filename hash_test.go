@@ -0,0 +1,109 @@
+package null_test
+
+import (
+	"hash/maphash"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+var seed = maphash.MakeSeed()
+
+func sum[V comparable](t null.T[V]) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	t.Hash(&h)
+	return h.Sum64()
+}
+
+type named struct {
+	A string
+	B string
+	_ int
+	c *int
+}
+
+type caseless string
+
+func (s caseless) Equal(t caseless) bool { return strings.EqualFold(string(s), string(t)) }
+
+func (s caseless) Hash(h *maphash.Hash) { h.WriteString(strings.ToLower(string(s))) }
+
+func TestHash(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	p := new(int)
+
+	t.Run("equal", func(t *testing.T) {
+		assertEqual(t, sum(null.From(1)), sum(null.From(1)))
+		assertEqual(t, sum(null.T[int]{}), sum(null.T[int]{}))
+		assertEqual(t, sum(null.From(at)), sum(null.From(at.In(time.FixedZone("JST", 9*60*60)))))
+		assertEqual(t, sum(null.From(at)), sum(null.From(time.Unix(at.Unix(), 6).Local())))
+		now := time.Now() // with a monotonic clock reading
+		assertEqual(t, sum(null.From(now)), sum(null.From(now.Round(0))))
+		assertEqual(t, sum(null.From(0.0)), sum(null.From(math.Copysign(0, -1))))
+		assertEqual(t, sum(null.From(named{A: "a", c: p})), sum(null.From(named{A: "a", c: p})))
+		assertEqual(t, sum(null.From(caseless("Go"))), sum(null.From(caseless("GO"))))
+		assertEqual(t, sum(null.From[any](at)), sum(null.From[any](at.Local())))
+		assertEqual(t, sum(null.From(struct{ T time.Time }{at})), sum(null.From(struct{ T time.Time }{at.Local()})))
+		assertEqual(t, sum(null.From([2]float32{0, 1})), sum(null.From([2]float32{float32(math.Copysign(0, -1)), 1})))
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		assertEqual(t, sum(null.From(0)) != sum(null.T[int]{}), true)
+		assertEqual(t, sum(null.From("")) != sum(null.T[string]{}), true)
+		assertEqual(t, sum(null.From(time.Time{})) != sum(null.T[time.Time]{}), true)
+		assertEqual(t, sum(null.From(1)) != sum(null.From(2)), true)
+		assertEqual(t, sum(null.From(named{A: "ab"})) != sum(null.From(named{A: "a", B: "b"})), true)
+		assertEqual(t, sum(null.From(named{c: p})) != sum(null.From(named{c: new(int)})), true)
+		assertEqual(t, sum(null.From[any](1)) != sum(null.From[any](int64(1))), true)
+		assertEqual(t, sum(null.From[any](nil)) != sum(null.T[any]{}), true)
+	})
+}
+
+func TestHash_Allocs(t *testing.T) {
+	var h maphash.Hash
+	x := null.From("x")
+	assertEqual(t, testing.AllocsPerRun(100, func() { x.Hash(&h) }), 0.0)
+}
+
+func FuzzHash_Time(f *testing.F) {
+	f.Add(int64(0), int64(0), 0)
+	f.Add(int64(1<<40), int64(999999999), -12*60*60)
+	f.Fuzz(func(t *testing.T, sec, nsec int64, offset int) {
+		x := null.From(time.Unix(sec, nsec))
+		y := null.From(time.Unix(sec, nsec).In(time.FixedZone("", offset%(24*60*60))))
+		if x.Equal(y) && sum(x) != sum(y) {
+			t.Errorf("%v and %v are equal, but their hashes differ", x, y)
+		}
+	})
+}
+
+func FuzzHash_Float(f *testing.F) {
+	f.Add(0.0, math.Copysign(0, -1))
+	f.Add(1.0, 1.0)
+	f.Fuzz(func(t *testing.T, a, b float64) {
+		x := null.From(struct{ A, B float64 }{a, b})
+		y := null.From(struct{ A, B float64 }{b, a})
+		if x.Equal(y) && sum(x) != sum(y) {
+			t.Errorf("%v and %v are equal, but their hashes differ", x, y)
+		}
+		if sum(x) != sum(x) {
+			t.Errorf("hash of %v is not deterministic", x)
+		}
+	})
+}
+
+func FuzzHash_String(f *testing.F) {
+	f.Add("a", "b", "ab", "")
+	f.Fuzz(func(t *testing.T, a, b, c, d string) {
+		x := null.From(named{A: a, B: b})
+		y := null.From(named{A: c, B: d})
+		if x.Equal(y) != (sum(x) == sum(y)) {
+			// Unequal values with the same hash are possible, but unlikely for a fuzzer to find.
+			t.Errorf("%v and %v: Equal is %v, but hashes equal is %v", x, y, x.Equal(y), sum(x) == sum(y))
+		}
+	})
+}
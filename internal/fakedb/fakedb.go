@@ -0,0 +1,91 @@
+// Package fakedb provides an in-memory database/sql driver for the tests of null and its subpackages.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// DB is a database whose queries all return Rows with Columns,
+// and which records the last query and its arguments.
+// Arguments are converted by database/sql as for a driver without a NamedValueChecker.
+type DB struct {
+	Columns []string
+	Rows    [][]driver.Value
+
+	mu    sync.Mutex
+	query string
+	args  []driver.NamedValue
+}
+
+// Open returns a database whose queries all return rows with columns.
+func Open(columns []string, rows ...[]driver.Value) *sql.DB {
+	return (&DB{Columns: columns, Rows: rows}).Open()
+}
+
+// Open returns a *sql.DB connected to db.
+func (db *DB) Open() *sql.DB {
+	return sql.OpenDB(connector{db})
+}
+
+// Query returns the last query.
+func (db *DB) Query() string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.query
+}
+
+// Args returns the arguments of the last query as received by the driver.
+func (db *DB) Args() []driver.NamedValue {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.args
+}
+
+func (db *DB) record(query string, args []driver.NamedValue) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.query, db.args = query, args
+}
+
+type connector struct{ db *DB }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return conn(c), nil }
+func (c connector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("fakedb: not supported") }
+
+type conn connector
+
+var _ driver.QueryerContext = conn{}
+
+func (conn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("fakedb: not supported") }
+func (conn) Close() error                        { return nil }
+func (conn) Begin() (driver.Tx, error)           { return nil, errors.New("fakedb: not supported") }
+
+func (c conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query, args)
+	return &rows{columns: c.db.Columns, rows: c.db.Rows}, nil
+}
+
+type rows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
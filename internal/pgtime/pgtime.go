@@ -0,0 +1,31 @@
+// Package pgtime parses the text of timestamps as PostgreSQL writes them.
+// It is not part of the API and is shared by the subpackages of null.
+package pgtime
+
+import (
+	"fmt"
+	"time"
+)
+
+// layouts are those of timestamps written by PostgreSQL with the ISO DateStyle,
+// whose time zone offset is omitted for timestamp and written as +09, +05:30 or +05:30:15 for timestamptz,
+// followed by those of RFC 3339 and dates.
+var layouts = []string{
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+	time.DateOnly,
+}
+
+// Parse parses s as a timestamp, with or without a time zone, an RFC 3339 time or a date.
+// A time without a time zone is returned in UTC.
+func Parse(s string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a timestamp", s)
+}
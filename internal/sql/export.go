@@ -0,0 +1,8 @@
+package sql
+
+// ConvertAssign copies to dest the value in src, converting it if possible,
+// in the same way as database/sql does for Rows.Scan.
+// It is not part of database/sql and is exported for the subpackages of null.
+func ConvertAssign(dest, src any) error {
+	return convertAssign(dest, src)
+}
@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package null
+
+import "iter"
+
+// All returns an iterator yielding the inner value V if t is not null, and nothing otherwise.
+func (t T[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if !t.IsNull() {
+			yield(t.v.V)
+		}
+	}
+}
+
+// Values returns an iterator yielding the inner values of the non-null elements of seq.
+func Values[V comparable](seq iter.Seq[T[V]]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for t := range seq {
+			if t.IsNull() {
+				continue
+			}
+			if !yield(t.v.V) {
+				return
+			}
+		}
+	}
+}
+
+// Compact returns an iterator yielding the inner values of the non-null elements of s in order.
+func Compact[V comparable](s []T[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, t := range s {
+			if t.IsNull() {
+				continue
+			}
+			if !yield(t.v.V) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+//go:build go1.23
+
+package null_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+)
+
+func TestAll(t *testing.T) {
+	assertEqualSlice(t, slices.Collect(null.From(3).All()), []int{3})
+	assertEqualSlice(t, slices.Collect(null.From(0).All()), []int{0})
+	assertEqualSlice(t, slices.Collect(null.T[int]{}.All()), []int(nil))
+
+	for v := range null.From("x").All() {
+		assertEqual(t, v, "x")
+		break
+	}
+}
+
+func TestValues(t *testing.T) {
+	ts := []null.T[int]{null.From(1), {}, null.From(0), {}, null.From(3)}
+	assertEqualSlice(t, slices.Collect(null.Values(slices.Values(ts))), []int{1, 0, 3})
+	assertEqualSlice(t, slices.Collect(null.Values(slices.Values([]null.T[int]{{}}))), []int(nil))
+
+	// Stops early.
+	var got []int
+	for v := range null.Values(slices.Values(ts)) {
+		got = append(got, v)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assertEqualSlice(t, got, []int{1, 0})
+}
+
+func TestCompact(t *testing.T) {
+	ts := []null.T[string]{{}, null.From("a"), {}, null.From(""), null.From("b")}
+	assertEqualSlice(t, slices.Collect(null.Compact(ts)), []string{"a", "", "b"})
+	assertEqualSlice(t, slices.Collect(null.Compact[string](nil)), []string(nil))
+
+	var got []string
+	for v := range null.Compact(ts) {
+		got = append(got, v)
+		break
+	}
+	assertEqualSlice(t, got, []string{"a"})
+}
+
+func TestIterators_Allocs(t *testing.T) {
+	ts := []null.T[int]{null.From(1), {}, null.From(3)}
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "Get", f: func() { _, _ = ts[0].Get() }},
+		{name: "All", f: func() {
+			for range ts[0].All() {
+			}
+		}},
+		{name: "Values", f: func() {
+			for range null.Values(slices.Values(ts)) {
+			}
+		}},
+		{name: "Compact", f: func() {
+			for range null.Compact(ts) {
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, testing.AllocsPerRun(100, tt.f), 0.0)
+		})
+	}
+}
+
+func assertEqualSlice[V comparable](t *testing.T, x, y []V) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
+
+var (
+	sink    int
+	ptrSink *int
+)
+
+func BenchmarkGet(b *testing.B) {
+	x := null.From(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v, _ := x.Get()
+		sink += v
+	}
+}
+
+// BenchmarkPtr is to compare with BenchmarkGet.
+func BenchmarkPtr(b *testing.B) {
+	x := null.From(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ptrSink = x.Ptr()
+	}
+}
+
+func BenchmarkAll(b *testing.B) {
+	x := null.From(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for v := range x.All() {
+			sink += v
+		}
+	}
+}
+
+func BenchmarkCompact(b *testing.B) {
+	ts := make([]null.T[int], 1000)
+	for i := range ts {
+		if i%2 == 0 {
+			ts[i] = null.From(i)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for v := range null.Compact(ts) {
+			sink += v
+		}
+	}
+}
+
+func BenchmarkValues(b *testing.B) {
+	ts := make([]null.T[int], 1000)
+	for i := range ts {
+		if i%2 == 0 {
+			ts[i] = null.From(i)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for v := range null.Values(slices.Values(ts)) {
+			sink += v
+		}
+	}
+}
+
+func ExampleCompact() {
+	scores := []null.T[int]{null.From(80), {}, null.From(65), {}}
+	total := 0
+	for v := range null.Compact(scores) {
+		total += v
+	}
+	fmt.Println(total)
+	// Output:
+	// 145
+}
@@ -0,0 +1,48 @@
+package mergepatch_test
+
+import (
+	"fmt"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/mergepatch"
+)
+
+func ExampleApply() {
+	type Profile struct {
+		Name     null.T[string] `json:"name"`
+		Nickname null.T[string] `json:"nickname"`
+		Email    null.T[string] `json:"email"`
+	}
+
+	p := Profile{Name: null.From("alice"), Nickname: null.From("ali"), Email: null.From("alice@example.com")}
+	// null deletes the nickname, and the email, which is absent, is kept.
+	if err := mergepatch.Apply(&p, []byte(`{"name":"Alice","nickname":null}`)); err != nil {
+		panic(err)
+	}
+	fmt.Println(p.Name, p.Nickname, p.Email)
+	// Output:
+	// Alice null alice@example.com
+}
+
+func ExampleDiff() {
+	type Address struct {
+		City null.T[string] `json:"city"`
+		Zip  null.T[string] `json:"zip"`
+	}
+	type Profile struct {
+		Name    null.T[string] `json:"name"`
+		Address Address        `json:"address"`
+	}
+
+	before := Profile{Name: null.From("alice"), Address: Address{City: null.From("Tokyo"), Zip: null.From("100-0001")}}
+	after := before
+	after.Address.Zip = null.T[string]{}
+
+	patch, err := mergepatch.Diff(before, after)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(patch))
+	// Output:
+	// {"address":{"zip":null}}
+}
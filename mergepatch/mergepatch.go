@@ -0,0 +1,607 @@
+// Package mergepatch applies and computes JSON Merge Patches (RFC 7386) for structs with null.T fields.
+//
+// A merge patch is a JSON object whose members replace those of the target:
+// null deletes a member, an object is merged recursively, and any other value replaces the member.
+// Members absent from the patch are left alone. For a struct, deleting a member sets the field to its zero value,
+// so that a null.T field becomes null:
+//
+//	// {"name":"alice","nickname":null} sets Name, sets Nickname to null and keeps Email.
+//	err := mergepatch.Apply(&user, patch)
+//
+// Diff computes the smallest patch between two values, such as snapshots before and after an update,
+// comparing null.T fields by null.T.Equal:
+//
+//	patch, err := mergepatch.Diff(before, after) // {"nickname":null}
+//
+// Members are matched to exported fields as by encoding/json: by the name in the json tag or,
+// without one, by the field name, preferring an exact match to a case-insensitive one.
+// Fields of embedded structs are promoted unless hidden by fields of the same name,
+// a field tagged json:"-" is ignored, and members matching no field are ignored.
+//
+// An object is merged into
+//
+//   - a struct, unless it implements json.Marshaler or json.Unmarshaler as time.Time does,
+//   - a map with string keys,
+//   - a value of type any, as a map[string]any decoded by encoding/json,
+//   - a pointer to, or the payload of a null.T or null.Sensitive of, one of them,
+//
+// a nil pointer or null value being merged as the zero value.
+// Any other value is replaced by the member decoded by encoding/json.
+// Apply copies maps and pointed values rather than modifying them, so values sharing them with the target are not affected.
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/qawatake/null"
+)
+
+// Apply applies the merge patch to *dst.
+// If it returns an error, *dst is left unchanged.
+func Apply[S any](dst *S, patch []byte) error {
+	if !json.Valid(patch) {
+		return errors.New("mergepatch: invalid JSON in patch")
+	}
+	v := *dst
+	if err := apply(reflect.ValueOf(&v).Elem(), patch); err != nil {
+		return fmt.Errorf("mergepatch: %w", err)
+	}
+	*dst = v
+	return nil
+}
+
+var nullBytes = []byte("null")
+
+func apply(v reflect.Value, patch json.RawMessage) error {
+	patch = bytes.TrimSpace(patch)
+	t := v.Type()
+	if bytes.Equal(patch, nullBytes) {
+		v.Set(reflect.Zero(t))
+		return nil
+	}
+	if patch[0] != '{' {
+		return replace(v, patch)
+	}
+	switch {
+	case isNullable(t):
+		n := v.Interface().(null.Nullable)
+		p := reflect.New(n.PayloadType()).Elem()
+		if !n.IsNull() {
+			setAny(p, n.Any())
+		}
+		if err := apply(p, patch); err != nil {
+			return err
+		}
+		r, err := null.FromAny(t, p.Interface())
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(r))
+		return nil
+	case t.Kind() == reflect.Pointer && (isObject(t.Elem()) || isMap(t.Elem())):
+		p := reflect.New(t.Elem())
+		if !v.IsNil() {
+			p.Elem().Set(v.Elem())
+		}
+		if err := apply(p.Elem(), patch); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	case t.Kind() == reflect.Interface && t.NumMethod() == 0:
+		var p any
+		if err := json.Unmarshal(patch, &p); err != nil {
+			return err
+		}
+		setAny(v, mergeAny(v.Interface(), p))
+		return nil
+	case isObject(t):
+		return applyStruct(v, patch)
+	case isMap(t):
+		return applyMap(v, patch)
+	}
+	return replace(v, patch)
+}
+
+func applyStruct(v reflect.Value, patch json.RawMessage) error {
+	fs := fieldsOf(v.Type())
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(members) {
+		f, ok := fs.lookup(name)
+		if !ok {
+			continue
+		}
+		if err := apply(v.FieldByIndex(f.index), members[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func applyMap(v reflect.Value, patch json.RawMessage) error {
+	t := v.Type()
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil {
+		return err
+	}
+	m := reflect.MakeMapWithSize(t, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		m.SetMapIndex(iter.Key(), iter.Value())
+	}
+	for _, name := range sortedKeys(members) {
+		k := reflect.ValueOf(name).Convert(t.Key())
+		if bytes.Equal(bytes.TrimSpace(members[name]), nullBytes) {
+			m.SetMapIndex(k, reflect.Value{})
+			continue
+		}
+		e := reflect.New(t.Elem()).Elem()
+		if cur := m.MapIndex(k); cur.IsValid() {
+			e.Set(cur)
+		}
+		if err := apply(e, members[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		m.SetMapIndex(k, e)
+	}
+	v.Set(m)
+	return nil
+}
+
+// replace sets v to the patch decoded by encoding/json.
+func replace(v reflect.Value, patch json.RawMessage) error {
+	p := reflect.New(v.Type())
+	if err := json.Unmarshal(patch, p.Interface()); err != nil {
+		return err
+	}
+	v.Set(p.Elem())
+	return nil
+}
+
+// mergeAny merges patch into target as decoded into values of type any, following RFC 7386.
+func mergeAny(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, _ := target.(map[string]any)
+	r := make(map[string]any, len(t)+len(p))
+	for k, v := range t {
+		r[k] = v
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(r, k)
+		} else {
+			r[k] = mergeAny(r[k], v)
+		}
+	}
+	return r
+}
+
+// Diff returns the smallest merge patch which turns from into to.
+// Fields of type null.T and null.Sensitive are compared in the sense of null.T.Equal,
+// other fields by their method Equal if they have one, as time.Time does, and by reflect.DeepEqual otherwise.
+// A member whose value is null in a map[string]any cannot be represented in a merge patch,
+// so it is treated as absent.
+// The members of the patch are in the order of the fields and, for maps, of the keys.
+// The payloads of null.Sensitive fields are written to the patch, though their MarshalJSON redacts them.
+func Diff[S any](from, to S) ([]byte, error) {
+	p, changed, err := diff(reflect.ValueOf(&from).Elem(), reflect.ValueOf(&to).Elem())
+	if err != nil {
+		return nil, fmt.Errorf("mergepatch: %w", err)
+	}
+	if !changed {
+		return []byte("{}"), nil
+	}
+	return p, nil
+}
+
+// diff returns the merge patch turning a into b, and reports whether a and b differ.
+func diff(a, b reflect.Value) (json.RawMessage, bool, error) {
+	t := a.Type()
+	switch {
+	case isNullable(t):
+		an, bn := a.Interface().(null.Nullable), b.Interface().(null.Nullable)
+		if nullableEqual(an, bn) {
+			return nil, false, nil
+		}
+		if an.IsNull() || bn.IsNull() {
+			return marshal(b)
+		}
+		pa, pb := reflect.New(an.PayloadType()).Elem(), reflect.New(bn.PayloadType()).Elem()
+		setAny(pa, an.Any())
+		setAny(pb, bn.Any())
+		return diff(pa, pb)
+	case t.Kind() == reflect.Pointer && (isObject(t.Elem()) || isMap(t.Elem())):
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() && b.IsNil() {
+				return nil, false, nil
+			}
+			return marshal(b)
+		}
+		return diff(a.Elem(), b.Elem())
+	case t.Kind() == reflect.Interface && t.NumMethod() == 0:
+		return diffAny(a.Interface(), b.Interface())
+	case isObject(t):
+		return diffStruct(a, b)
+	case isMap(t):
+		if a.IsNil() != b.IsNil() {
+			return marshal(b)
+		}
+		return diffMap(a, b)
+	}
+	if equal(a, b) {
+		return nil, false, nil
+	}
+	return marshal(b)
+}
+
+func diffStruct(a, b reflect.Value) (json.RawMessage, bool, error) {
+	fs := fieldsOf(a.Type())
+	var o object
+	for _, f := range fs.list {
+		p, changed, err := diff(a.FieldByIndex(f.index), b.FieldByIndex(f.index))
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", f.name, err)
+		}
+		if changed {
+			o.add(f.name, p)
+		}
+	}
+	return o.bytes()
+}
+
+func diffMap(a, b reflect.Value) (json.RawMessage, bool, error) {
+	keys := make(map[string]reflect.Value)
+	for _, k := range a.MapKeys() {
+		keys[k.String()] = k
+	}
+	for _, k := range b.MapKeys() {
+		keys[k.String()] = k
+	}
+	var o object
+	for _, name := range sortedKeys(keys) {
+		k := keys[name]
+		ae, be := a.MapIndex(k), b.MapIndex(k)
+		switch {
+		case !be.IsValid():
+			o.add(name, nullBytes)
+		case !ae.IsValid():
+			p, _, err := marshal(be)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", name, err)
+			}
+			o.add(name, p)
+		default:
+			p, changed, err := diff(ae, be)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", name, err)
+			}
+			if changed {
+				o.add(name, p)
+			}
+		}
+	}
+	return o.bytes()
+}
+
+// diffAny is diff for values decoded into values of type any.
+func diffAny(a, b any) (json.RawMessage, bool, error) {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if !aok || !bok {
+		if reflect.DeepEqual(a, b) {
+			return nil, false, nil
+		}
+		p, err := json.Marshal(b)
+		if err != nil {
+			return nil, false, err
+		}
+		return p, true, nil
+	}
+	keys := make(map[string]struct{})
+	for k := range am {
+		keys[k] = struct{}{}
+	}
+	for k := range bm {
+		keys[k] = struct{}{}
+	}
+	var o object
+	for _, k := range sortedKeys(keys) {
+		av, bv := am[k], bm[k]
+		if bv == nil {
+			if av != nil {
+				o.add(k, nullBytes)
+			}
+			continue
+		}
+		p, changed, err := diffAny(av, bv)
+		if err != nil {
+			return nil, false, err
+		}
+		if changed {
+			o.add(k, p)
+		}
+	}
+	return o.bytes()
+}
+
+// marshal returns the JSON encoding of v, and true as diff does for a changed value.
+// Unlike json.Marshal, it writes the payloads of null.Sensitive, whose MarshalJSON redacts them,
+// since a patch carries them deliberately.
+func marshal(v reflect.Value) (json.RawMessage, bool, error) {
+	t := v.Type()
+	switch {
+	case isNullable(t):
+		n := v.Interface().(null.Nullable)
+		if n.IsNull() {
+			return nullBytes, true, nil
+		}
+		p := reflect.New(n.PayloadType()).Elem()
+		setAny(p, n.Any())
+		return marshal(p)
+	case t.Kind() == reflect.Pointer && (isObject(t.Elem()) || isMap(t.Elem())):
+		if v.IsNil() {
+			return nullBytes, true, nil
+		}
+		return marshal(v.Elem())
+	case isObject(t):
+		var o object
+		for _, f := range fieldsOf(t).list {
+			p, _, err := marshal(v.FieldByIndex(f.index))
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", f.name, err)
+			}
+			o.add(f.name, p)
+		}
+		return o.object()
+	case isMap(t) && !v.IsNil():
+		keys := make(map[string]reflect.Value)
+		for _, k := range v.MapKeys() {
+			keys[k.String()] = k
+		}
+		var o object
+		for _, name := range sortedKeys(keys) {
+			p, _, err := marshal(v.MapIndex(keys[name]))
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", name, err)
+			}
+			o.add(name, p)
+		}
+		return o.object()
+	}
+	p, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, false, err
+	}
+	return p, true, nil
+}
+
+// object builds a JSON object from members in order.
+type object struct {
+	buf bytes.Buffer
+}
+
+func (o *object) add(name string, value json.RawMessage) {
+	if o.buf.Len() == 0 {
+		o.buf.WriteByte('{')
+	} else {
+		o.buf.WriteByte(',')
+	}
+	k, _ := json.Marshal(name)
+	o.buf.Write(k)
+	o.buf.WriteByte(':')
+	o.buf.Write(value)
+}
+
+// object returns the object, which may have no members, and true.
+func (o *object) object() (json.RawMessage, bool, error) {
+	if o.buf.Len() == 0 {
+		return json.RawMessage("{}"), true, nil
+	}
+	p, _, err := o.bytes()
+	return p, true, err
+}
+
+// bytes returns the object and reports whether it has any members.
+func (o *object) bytes() (json.RawMessage, bool, error) {
+	if o.buf.Len() == 0 {
+		return nil, false, nil
+	}
+	o.buf.WriteByte('}')
+	return o.buf.Bytes(), true, nil
+}
+
+// nullableEqual reports whether a and b are equal in the sense of null.T.Equal.
+func nullableEqual(a, b null.Nullable) bool {
+	if a.IsNull() || b.IsNull() {
+		return a.IsNull() && b.IsNull()
+	}
+	av, bv := a.Any(), b.Any()
+	if av == nil || bv == nil {
+		return av == nil && bv == nil
+	}
+	if m := reflect.ValueOf(av).MethodByName("Equal"); isEqualMethod(m, reflect.TypeOf(av)) && reflect.TypeOf(bv) == reflect.TypeOf(av) {
+		return m.Call([]reflect.Value{reflect.ValueOf(bv)})[0].Bool()
+	}
+	if !reflect.TypeOf(av).Comparable() {
+		// A payload of type any may hold a map or slice decoded from JSON, for which == panics.
+		return reflect.DeepEqual(av, bv)
+	}
+	return av == bv
+}
+
+// equal reports whether a and b are equal by their method Equal or by reflect.DeepEqual.
+func equal(a, b reflect.Value) bool {
+	if m := a.MethodByName("Equal"); isEqualMethod(m, a.Type()) {
+		return m.Call([]reflect.Value{b})[0].Bool()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+var boolType = reflect.TypeOf(false)
+
+func isEqualMethod(m reflect.Value, t reflect.Type) bool {
+	if !m.IsValid() {
+		return false
+	}
+	mt := m.Type()
+	return mt.NumIn() == 1 && mt.In(0) == t && mt.NumOut() == 1 && mt.Out(0) == boolType
+}
+
+// setAny sets v to x, or to the zero value if x is nil.
+func setAny(v reflect.Value, x any) {
+	if x == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+	v.Set(reflect.ValueOf(x))
+}
+
+var (
+	nullableType    = reflect.TypeOf((*null.Nullable)(nil)).Elem()
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// isNullable reports whether t is an instance of null.T or null.Sensitive.
+// A struct type embedding one is not, and is encoded by the promoted methods as a whole.
+func isNullable(t reflect.Type) bool {
+	_, err := null.FromAny(t, nil)
+	return err == nil
+}
+
+// isObject reports whether t is a struct type encoded as a JSON object of its fields.
+func isObject(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !hasJSONMethods(t)
+}
+
+// isMap reports whether t is a map type encoded as a JSON object of its entries.
+func isMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && !hasJSONMethods(t) && !hasJSONMethods(t.Key())
+}
+
+func hasJSONMethods(t reflect.Type) bool {
+	p := reflect.PointerTo(t)
+	return t.Implements(nullableType) || p.Implements(marshalerType) || p.Implements(unmarshalerType)
+}
+
+// field is an exported field of a struct, possibly promoted from an embedded struct.
+type field struct {
+	name   string
+	index  []int
+	tagged bool // whether name comes from a json tag
+	depth  int  // the number of embedded structs the field is promoted through
+}
+
+// fields are the fields of a struct type in order.
+type fields struct {
+	list   []field
+	byName map[string]field
+}
+
+// lookup returns the field named name, or else one named name case-insensitively, as encoding/json does.
+func (fs *fields) lookup(name string) (field, bool) {
+	if f, ok := fs.byName[name]; ok {
+		return f, true
+	}
+	for _, f := range fs.list {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+var cache sync.Map // map[reflect.Type]*fields
+
+// fieldsOf returns the fields of t, resolving fields of the same name as encoding/json does:
+// the shallowest one is used, preferring a tagged one at the same depth,
+// and the name is dropped if that does not single out a field.
+func fieldsOf(t reflect.Type) *fields {
+	if fs, ok := cache.Load(t); ok {
+		return fs.(*fields)
+	}
+	var all []field
+	collect(t, nil, 0, &all)
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].name != all[j].name {
+			return all[i].name < all[j].name
+		}
+		if all[i].depth != all[j].depth {
+			return all[i].depth < all[j].depth
+		}
+		return all[i].tagged && !all[j].tagged
+	})
+
+	fs := &fields{byName: make(map[string]field)}
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].name == all[i].name {
+			j++
+		}
+		if j-i == 1 || all[i].depth != all[i+1].depth || all[i].tagged != all[i+1].tagged {
+			fs.list = append(fs.list, all[i])
+		}
+		i = j
+	}
+	sort.Slice(fs.list, func(i, j int) bool {
+		x, y := fs.list[i].index, fs.list[j].index
+		for k := 0; k < len(x) && k < len(y); k++ {
+			if x[k] != y[k] {
+				return x[k] < y[k]
+			}
+		}
+		return len(x) < len(y)
+	})
+	for _, f := range fs.list {
+		fs.byName[f.name] = f
+	}
+	v, _ := cache.LoadOrStore(t, fs)
+	return v.(*fields)
+}
+
+// collect appends the fields of t, including those of embedded structs, to all.
+func collect(t reflect.Type, index []int, depth int, all *[]field) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		idx := append(index[:len(index):len(index)], i)
+		if f.Anonymous && name == "" && isObject(f.Type) {
+			collect(f.Type, idx, depth+1, all)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = f.Name
+		}
+		*all = append(*all, field{name: name, index: idx, tagged: tagged, depth: depth})
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
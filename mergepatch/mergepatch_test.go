@@ -0,0 +1,461 @@
+package mergepatch_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/mergepatch"
+)
+
+// rfcExamples are the examples of Appendix A of RFC 7386.
+var rfcExamples = []struct {
+	original string
+	patch    string
+	result   string
+}{
+	{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+	{`{"a":"b"}`, `{"a":null}`, `{}`},
+	{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+	{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+	{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+	{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+	{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+	{`{"a":"b"}`, `["c"]`, `["c"]`},
+	{`{"a":"foo"}`, `null`, `null`},
+	{`{"a":"foo"}`, `"bar"`, `"bar"`},
+	{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+	{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+	{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+}
+
+func TestApply_RFCExamples(t *testing.T) {
+	// A null.T[any] holds any JSON document.
+	for _, tt := range rfcExamples {
+		tt := tt
+		t.Run(tt.original+" "+tt.patch, func(t *testing.T) {
+			var doc null.T[any]
+			requireNoError(t, json.Unmarshal([]byte(tt.original), &doc))
+			requireNoError(t, mergepatch.Apply(&doc, []byte(tt.patch)))
+			assertJSONEqual(t, doc, tt.result)
+		})
+	}
+}
+
+// document holds the members of the RFC examples which are objects.
+type document struct {
+	A null.T[any] `json:"a"`
+	B null.T[any] `json:"b"`
+	C null.T[any] `json:"c"`
+	E null.T[any] `json:"e"`
+}
+
+func TestApply_RFCExamples_Struct(t *testing.T) {
+	for _, tt := range rfcExamples {
+		tt := tt
+		t.Run(tt.original+" "+tt.patch, func(t *testing.T) {
+			var doc document
+			if err := json.Unmarshal([]byte(tt.original), &doc); err != nil {
+				t.Skip("the original is not an object")
+			}
+			err := mergepatch.Apply(&doc, []byte(tt.patch))
+			var want document
+			if json.Unmarshal([]byte(tt.result), &want) != nil {
+				// A patch which is not an object replaces the whole document, which must then be an object.
+				requireError(t, err)
+				return
+			}
+			requireNoError(t, err)
+			assertJSONEqual(t, doc, mustMarshal(t, want))
+		})
+	}
+}
+
+func TestDiff_RFCExamples(t *testing.T) {
+	for _, tt := range rfcExamples {
+		tt := tt
+		t.Run(tt.original+" "+tt.result, func(t *testing.T) {
+			var from, to null.T[any]
+			requireNoError(t, json.Unmarshal([]byte(tt.original), &from))
+			requireNoError(t, json.Unmarshal([]byte(tt.result), &to))
+			patch, err := mergepatch.Diff(from, to)
+			requireNoError(t, err)
+			requireNoError(t, mergepatch.Apply(&from, patch))
+			assertJSONEqual(t, from, tt.result)
+		})
+	}
+}
+
+func TestDiff_RFCExamples_Minimal(t *testing.T) {
+	tests := []struct {
+		original string
+		result   string
+		want     string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{}`, `{"a":null}`},
+		{`{"a":"b","b":"c"}`, `{"b":"c"}`, `{"a":null}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d"}}`, `{"a":{"b":"d"}}`},
+		{`{"e":null}`, `{"e":null,"a":1}`, `{"a":1}`},
+		{`{}`, `{"a":{"bb":{}}}`, `{"a":{"bb":{}}}`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `{"a":"foo"}`, `{}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.original+" "+tt.result, func(t *testing.T) {
+			var from, to null.T[any]
+			requireNoError(t, json.Unmarshal([]byte(tt.original), &from))
+			requireNoError(t, json.Unmarshal([]byte(tt.result), &to))
+			patch, err := mergepatch.Diff(from, to)
+			requireNoError(t, err)
+			assertEqual(t, string(patch), tt.want)
+		})
+	}
+}
+
+type Address struct {
+	City null.T[string] `json:"city"`
+	Zip  null.T[string] `json:"zip"`
+}
+
+type Audit struct {
+	UpdatedBy null.T[string] `json:"updated_by"`
+}
+
+type User struct {
+	Audit
+	Name     null.T[string]         `json:"name"`
+	Nickname null.T[string]         `json:"nickname,omitempty"`
+	Age      null.T[int]            `json:"age"`
+	Birthday null.T[time.Time]      `json:"birthday"`
+	Address  Address                `json:"address"`
+	Billing  null.T[Address]        `json:"billing"`
+	Manager  *User                  `json:"manager"`
+	Labels   map[string]string      `json:"labels"`
+	Password null.Sensitive[string] `json:"password"`
+	Tags     []string               `json:"tags"`
+	Internal string                 `json:"-"`
+}
+
+var birthday = time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func newUser() User {
+	return User{
+		Audit:    Audit{UpdatedBy: null.From("system")},
+		Name:     null.From("alice"),
+		Nickname: null.From("ali"),
+		Age:      null.From(30),
+		Birthday: null.From(birthday),
+		Address:  Address{City: null.From("Tokyo"), Zip: null.From("100-0001")},
+		Labels:   map[string]string{"team": "db", "site": "hq"},
+		Tags:     []string{"a"},
+		Internal: "internal",
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  func(u *User)
+	}{
+		{
+			name:  "empty",
+			patch: `{}`,
+			want:  func(u *User) {},
+		},
+		{
+			name:  "set and delete",
+			patch: `{"name":"bob","nickname":null}`,
+			want: func(u *User) {
+				u.Name = null.From("bob")
+				u.Nickname = null.T[string]{}
+			},
+		},
+		{
+			name:  "nested struct is merged",
+			patch: `{"address":{"city":"Osaka"}}`,
+			want:  func(u *User) { u.Address.City = null.From("Osaka") },
+		},
+		{
+			name:  "nested struct is deleted",
+			patch: `{"address":null}`,
+			want:  func(u *User) { u.Address = Address{} },
+		},
+		{
+			name:  "null payload is merged as zero",
+			patch: `{"billing":{"zip":"530-0001","country":"JP"}}`,
+			want:  func(u *User) { u.Billing = null.From(Address{Zip: null.From("530-0001")}) },
+		},
+		{
+			name:  "nil pointer is merged as zero",
+			patch: `{"manager":{"name":"carol"}}`,
+			want:  func(u *User) { u.Manager = &User{Name: null.From("carol")} },
+		},
+		{
+			name:  "map is merged",
+			patch: `{"labels":{"team":"web","site":null,"floor":"3"}}`,
+			want:  func(u *User) { u.Labels = map[string]string{"team": "web", "floor": "3"} },
+		},
+		{
+			name:  "array is replaced",
+			patch: `{"tags":["b","c"]}`,
+			want:  func(u *User) { u.Tags = []string{"b", "c"} },
+		},
+		{
+			name:  "time is replaced",
+			patch: `{"birthday":"2001-02-03T00:00:00Z"}`,
+			want:  func(u *User) { u.Birthday = null.From(time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)) },
+		},
+		{
+			name:  "sensitive",
+			patch: `{"password":"secret"}`,
+			want:  func(u *User) { u.Password = null.Sensitive[string]{T: null.From("secret")} },
+		},
+		{
+			name:  "embedded field is promoted",
+			patch: `{"updated_by":"admin"}`,
+			want:  func(u *User) { u.UpdatedBy = null.From("admin") },
+		},
+		{
+			name:  "case-insensitive",
+			patch: `{"NAME":"bob"}`,
+			want:  func(u *User) { u.Name = null.From("bob") },
+		},
+		{
+			name:  "unknown and ignored members",
+			patch: `{"unknown":1,"Internal":"x","-":"x"}`,
+			want:  func(u *User) {},
+		},
+		{
+			name:  "whole document deleted",
+			patch: `null`,
+			want:  func(u *User) { *u = User{} },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := newUser()
+			requireNoError(t, mergepatch.Apply(&got, []byte(tt.patch)))
+			want := newUser()
+			tt.want(&want)
+			assertEqual(t, got, want)
+		})
+	}
+}
+
+func TestApply_DoesNotModifyShared(t *testing.T) {
+	u := newUser()
+	labels := u.Labels
+	manager := &User{Name: null.From("carol")}
+	u.Manager = manager
+	requireNoError(t, mergepatch.Apply(&u, []byte(`{"labels":{"team":null},"manager":{"name":"dave"}}`)))
+	assertEqual(t, labels, map[string]string{"team": "db", "site": "hq"})
+	assertEqual(t, manager.Name, null.From("carol"))
+	assertEqual(t, u.Manager.Name, null.From("dave"))
+}
+
+func TestApply_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{name: "invalid JSON", patch: `{"name":`},
+		{name: "empty", patch: ``},
+		{name: "mismatched type", patch: `{"name":"bob","age":"thirty"}`},
+		{name: "mismatched nested type", patch: `{"address":{"city":1}}`},
+		{name: "object into scalar", patch: `{"age":{"years":30}}`},
+		{name: "not an object", patch: `["a"]`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := newUser()
+			requireError(t, mergepatch.Apply(&got, []byte(tt.patch)))
+			assertEqual(t, got, newUser())
+		})
+	}
+}
+
+func TestApply_Embedded(t *testing.T) {
+	type A struct {
+		Name null.T[string]
+	}
+	type B struct {
+		Name null.T[string]
+	}
+	type Tagged struct {
+		Name null.T[string] `json:"Name"`
+	}
+
+	t.Run("shallower field hides embedded ones", func(t *testing.T) {
+		type S struct {
+			A
+			B
+			Name null.T[string]
+		}
+		var v S
+		requireNoError(t, mergepatch.Apply(&v, []byte(`{"Name":"x"}`)))
+		assertEqual(t, v, S{Name: null.From("x")})
+	})
+
+	t.Run("conflict at the same depth is dropped", func(t *testing.T) {
+		type S struct {
+			A
+			B
+		}
+		var v S
+		requireNoError(t, mergepatch.Apply(&v, []byte(`{"Name":"x"}`)))
+		assertEqual(t, v, S{})
+		patch, err := mergepatch.Diff(v, S{A: A{Name: null.From("x")}})
+		requireNoError(t, err)
+		assertEqual(t, string(patch), `{}`)
+	})
+
+	t.Run("tagged field wins at the same depth", func(t *testing.T) {
+		type S struct {
+			A
+			Tagged
+		}
+		var v S
+		requireNoError(t, mergepatch.Apply(&v, []byte(`{"Name":"x"}`)))
+		assertEqual(t, v, S{Tagged: Tagged{Name: null.From("x")}})
+	})
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		to   func(u *User)
+		want string
+	}{
+		{
+			name: "equal",
+			to:   func(u *User) {},
+			want: `{}`,
+		},
+		{
+			name: "equal time in another location",
+			to:   func(u *User) { u.Birthday = null.From(birthday.In(time.FixedZone("JST", 9*60*60))) },
+			want: `{}`,
+		},
+		{
+			name: "set and delete",
+			to: func(u *User) {
+				u.Name = null.From("bob")
+				u.Nickname = null.T[string]{}
+			},
+			want: `{"name":"bob","nickname":null}`,
+		},
+		{
+			name: "nested struct",
+			to:   func(u *User) { u.Address.Zip = null.T[string]{} },
+			want: `{"address":{"zip":null}}`,
+		},
+		{
+			name: "null payload",
+			to:   func(u *User) { u.Billing = null.From(Address{City: null.From("Osaka")}) },
+			want: `{"billing":{"city":"Osaka","zip":null}}`,
+		},
+		{
+			name: "pointer",
+			to:   func(u *User) { u.Manager = &User{Name: null.From("carol")} },
+			want: `{"manager":{"updated_by":null,"name":"carol","nickname":null,"age":null,"birthday":null,"address":{"city":null,"zip":null},"billing":null,"manager":null,"labels":null,"password":null,"tags":null}}`,
+		},
+		{
+			name: "map",
+			to:   func(u *User) { u.Labels = map[string]string{"team": "web", "floor": "3"} },
+			want: `{"labels":{"floor":"3","site":null,"team":"web"}}`,
+		},
+		{
+			name: "array",
+			to:   func(u *User) { u.Tags = append(u.Tags, "b") },
+			want: `{"tags":["a","b"]}`,
+		},
+		{
+			name: "embedded and sensitive",
+			to: func(u *User) {
+				u.UpdatedBy = null.From("admin")
+				u.Password = null.Sensitive[string]{T: null.From("secret")}
+			},
+			want: `{"updated_by":"admin","password":"secret"}`,
+		},
+		{
+			name: "sensitive in a new struct",
+			to: func(u *User) {
+				u.Manager = &User{Password: null.Sensitive[string]{T: null.From("secret")}, Labels: map[string]string{"k": "v"}}
+			},
+			want: `{"manager":{"updated_by":null,"name":null,"nickname":null,"age":null,"birthday":null,"address":{"city":null,"zip":null},"billing":null,"manager":null,"labels":{"k":"v"},"password":"secret","tags":null}}`,
+		},
+		{
+			name: "ignored field",
+			to:   func(u *User) { u.Internal = "changed" },
+			want: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := newUser(), newUser()
+			tt.to(&to)
+			patch, err := mergepatch.Diff(from, to)
+			requireNoError(t, err)
+			assertEqual(t, string(patch), tt.want)
+
+			// Applying the patch turns from into to.
+			requireNoError(t, mergepatch.Apply(&from, patch))
+			from.Internal = to.Internal
+			assertEqual(t, from, to)
+		})
+	}
+}
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
+
+// assertJSONEqual asserts that x is encoded as the JSON want, up to the order of members.
+// Documents are compared as JSON because null.T.Equal panics for a payload of type any holding a map.
+func assertJSONEqual(t *testing.T, x any, want string) bool {
+	t.Helper()
+	var got, w any
+	requireNoError(t, json.Unmarshal([]byte(mustMarshal(t, x)), &got))
+	requireNoError(t, json.Unmarshal([]byte(want), &w))
+	return assertEqual(t, got, w)
+}
+
+func mustMarshal(t *testing.T, x any) string {
+	t.Helper()
+	b, err := json.Marshal(x)
+	requireNoError(t, err)
+	return string(b)
+}
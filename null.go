@@ -5,10 +5,8 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
-
-	// can replace with "database/sql" after go1.22
-	// https://github.com/golang/go/issues/60370
-	sql1_22 "github.com/qawatake/null/internal/sql"
+	"errors"
+	"fmt"
 )
 
 // MEMO: This package does not provide NewXXX functions.
@@ -29,13 +27,13 @@ import (
 //
 // [strictly comparable]: https://go.dev/ref/spec#Comparison_operators
 type T[V comparable] struct {
-	v sql1_22.Null[V]
+	v nullable[V]
 }
 
 // From creates a new T that is valid.
 func From[V comparable](v V) T[V] {
 	return T[V]{
-		v: sql1_22.Null[V]{
+		v: nullable[V]{
 			V:     v,
 			Valid: true,
 		},
@@ -153,5 +151,24 @@ func (t T[V]) IsNull() bool {
 	return !t.v.Valid
 }
 
+// ErrNull is the error with which MustGet panics if the value is null.
+var ErrNull = errors.New("null: value is null")
+
+// Get returns the inner value V and true if t is not null.
+// Otherwise, it returns the zero value of V and false.
+// Unlike Ptr, it does not allocate even if the result outlives the caller.
+func (t T[V]) Get() (V, bool) {
+	return t.ValueOrZero(), !t.IsNull()
+}
+
+// MustGet returns the inner value V.
+// It panics with an error wrapping [ErrNull] if t is null.
+func (t T[V]) MustGet() V {
+	if t.IsNull() {
+		panic(fmt.Errorf("%w: %#v", ErrNull, t))
+	}
+	return t.v.V
+}
+
 // nullBytes is a JSON null literal
 var nullBytes = []byte("null")
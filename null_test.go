@@ -1,6 +1,7 @@
 package null_test
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -117,22 +118,7 @@ func TestEqual(t *testing.T) {
 
 func TestScan(t *testing.T) {
 	t.Run("Bool", func(t *testing.T) {
-		tests := []scanTestCase[bool]{
-			{
-				name:             format("true"),
-				src:              true,
-				wantValue:        true,
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format(nil),
-				src:              nil,
-				wantValue:        false,
-				wantIsNull:       true,
-				requireErrorFunc: requireNoError,
-			},
-		}
+		tests := scanTestCasesBool
 
 		for _, tt := range tests {
 			tt := tt
@@ -147,29 +133,7 @@ func TestScan(t *testing.T) {
 	})
 
 	t.Run("Float64", func(t *testing.T) {
-		tests := []scanTestCase[float64]{
-			{
-				name:             format(1.2345),
-				src:              1.2345,
-				wantValue:        1.2345,
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format("1.2345"),
-				src:              "1.2345",
-				wantValue:        1.2345,
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format(nil),
-				src:              nil,
-				wantValue:        0,
-				wantIsNull:       true,
-				requireErrorFunc: requireNoError,
-			},
-		}
+		tests := scanTestCasesFloat64
 
 		for _, tt := range tests {
 			tt := tt
@@ -184,22 +148,7 @@ func TestScan(t *testing.T) {
 	})
 
 	t.Run("Int64", func(t *testing.T) {
-		tests := []scanTestCase[int]{
-			{
-				name:             format(int64(12345)),
-				src:              int64(12345),
-				wantValue:        12345,
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format(nil),
-				src:              nil,
-				wantValue:        0,
-				wantIsNull:       true,
-				requireErrorFunc: requireNoError,
-			},
-		}
+		tests := scanTestCasesInt64
 
 		for _, tt := range tests {
 			tt := tt
@@ -214,22 +163,7 @@ func TestScan(t *testing.T) {
 	})
 
 	t.Run("String", func(t *testing.T) {
-		tests := []scanTestCase[string]{
-			{
-				name:             format("test"),
-				src:              "test",
-				wantValue:        "test",
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format(nil),
-				src:              nil,
-				wantValue:        "",
-				wantIsNull:       true,
-				requireErrorFunc: requireNoError,
-			},
-		}
+		tests := scanTestCasesString
 
 		for _, tt := range tests {
 			tt := tt
@@ -244,32 +178,7 @@ func TestScan(t *testing.T) {
 	})
 
 	t.Run("Time", func(t *testing.T) {
-		const timeString1 = "2012-12-21T21:21:21Z"
-		timeValue1, _ := time.Parse(time.RFC3339, timeString1)
-		tests := []scanTestCase[time.Time]{
-			{
-				name:             format(timeValue1),
-				src:              timeValue1,
-				wantValue:        time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC),
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format(nil),
-				src:              nil,
-				wantValue:        time.Time{},
-				wantIsNull:       true,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:      format(int64(42)),
-				src:       int64(42),
-				wantValue: time.Time{},
-				// database/sql Scan set Valid to true in this case.
-				wantIsNull:       true,
-				requireErrorFunc: requireError,
-			},
-		}
+		tests := scanTestCasesTime
 
 		for _, tt := range tests {
 			tt := tt
@@ -284,30 +193,7 @@ func TestScan(t *testing.T) {
 	})
 
 	t.Run("CustomScanner", func(t *testing.T) {
-
-		tests := []scanTestCase[customScanner]{
-			{
-				name:             format(true),
-				src:              true,
-				wantValue:        customScanner{"true"},
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format(int64(12345)),
-				src:              int64(12345),
-				wantValue:        customScanner{"12345"},
-				wantIsNull:       false,
-				requireErrorFunc: requireNoError,
-			},
-			{
-				name:             format(nil),
-				src:              nil,
-				wantValue:        customScanner{""},
-				wantIsNull:       true,
-				requireErrorFunc: requireNoError,
-			},
-		}
+		tests := scanTestCasesCustomScanner
 
 		for _, tt := range tests {
 			tt := tt
@@ -322,6 +208,133 @@ func TestScan(t *testing.T) {
 	})
 }
 
+var scanTestCasesBool = []scanTestCase[bool]{
+	{
+		name:             format("true"),
+		src:              true,
+		wantValue:        true,
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format(nil),
+		src:              nil,
+		wantValue:        false,
+		wantIsNull:       true,
+		requireErrorFunc: requireNoError,
+	},
+}
+
+var scanTestCasesFloat64 = []scanTestCase[float64]{
+	{
+		name:             format(1.2345),
+		src:              1.2345,
+		wantValue:        1.2345,
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format("1.2345"),
+		src:              "1.2345",
+		wantValue:        1.2345,
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format(nil),
+		src:              nil,
+		wantValue:        0,
+		wantIsNull:       true,
+		requireErrorFunc: requireNoError,
+	},
+}
+
+var scanTestCasesInt64 = []scanTestCase[int]{
+	{
+		name:             format(int64(12345)),
+		src:              int64(12345),
+		wantValue:        12345,
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format(nil),
+		src:              nil,
+		wantValue:        0,
+		wantIsNull:       true,
+		requireErrorFunc: requireNoError,
+	},
+}
+
+var scanTestCasesString = []scanTestCase[string]{
+	{
+		name:             format("test"),
+		src:              "test",
+		wantValue:        "test",
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format(nil),
+		src:              nil,
+		wantValue:        "",
+		wantIsNull:       true,
+		requireErrorFunc: requireNoError,
+	},
+}
+
+// scanTimeValue1 is 2012-12-21T21:21:21Z parsed as RFC 3339.
+var scanTimeValue1, _ = time.Parse(time.RFC3339, "2012-12-21T21:21:21Z")
+
+var scanTestCasesTime = []scanTestCase[time.Time]{
+	{
+		name:             format(scanTimeValue1),
+		src:              scanTimeValue1,
+		wantValue:        time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC),
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format(nil),
+		src:              nil,
+		wantValue:        time.Time{},
+		wantIsNull:       true,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:      format(int64(42)),
+		src:       int64(42),
+		wantValue: time.Time{},
+		// Older versions of database/sql.Null set Valid to true in this case.
+		wantIsNull:       true,
+		requireErrorFunc: requireError,
+	},
+}
+
+var scanTestCasesCustomScanner = []scanTestCase[customScanner]{
+	{
+		name:             format(true),
+		src:              true,
+		wantValue:        customScanner{"true"},
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format(int64(12345)),
+		src:              int64(12345),
+		wantValue:        customScanner{"12345"},
+		wantIsNull:       false,
+		requireErrorFunc: requireNoError,
+	},
+	{
+		name:             format(nil),
+		src:              nil,
+		wantValue:        customScanner{""},
+		wantIsNull:       true,
+		requireErrorFunc: requireNoError,
+	},
+}
+
 func TestUnmarshalJSON(t *testing.T) {
 	t.Run("Bool", func(t *testing.T) {
 		tests := []unmarshalJSONTestCase[bool]{
@@ -847,6 +860,70 @@ func TestFromPtr(t *testing.T) {
 	})
 }
 
+func TestGet(t *testing.T) {
+	v, ok := null.From(3).Get()
+	assertEqual(t, v, 3)
+	assertEqual(t, ok, true)
+
+	v, ok = null.From(0).Get()
+	assertEqual(t, v, 0)
+	assertEqual(t, ok, true)
+
+	v, ok = null.T[int]{}.Get()
+	assertEqual(t, v, 0)
+	assertEqual(t, ok, false)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = null.From("x").Get()
+	})
+	assertEqual(t, allocs, 0.0)
+}
+
+func TestMustGet(t *testing.T) {
+	assertEqual(t, null.From("x").MustGet(), "x")
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok {
+			t.Fatal("want panic with error")
+		}
+		if !errors.Is(err, null.ErrNull) {
+			t.Errorf("want ErrNull, but got %v", err)
+		}
+		assertEqual(t, err.Error(), "null: value is null: null.T[string]{}")
+	}()
+	null.T[string]{}.MustGet()
+}
+
+// TestValue checks that Value converts the payload as sql.Null.Value does since go1.24.
+// Earlier versions of this package returned the payload as is, shown in before.
+func TestValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  driver.Valuer
+		want   driver.Value
+		before driver.Value
+	}{
+		{name: "int", value: null.From(3), want: int64(3), before: 3},
+		{name: "uint8", value: null.From(uint8(3)), want: int64(3), before: uint8(3)},
+		{name: "float32", value: null.From(float32(1.5)), want: float64(1.5), before: float32(1.5)},
+		{name: "time.Duration", value: null.From(time.Second), want: int64(time.Second), before: time.Second},
+		{name: "named string", value: null.From(json.Number("1")), want: "1", before: json.Number("1")},
+		{name: "int64", value: null.From(int64(3)), want: int64(3), before: int64(3)},
+		{name: "string", value: null.From("a"), want: "a", before: "a"},
+		{name: "null", value: null.T[int]{}, want: nil, before: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value.Value()
+			requireNoError(t, err)
+			assertEqual(t, got, tt.want)
+		})
+	}
+}
+
 func Test_SharedValues(t *testing.T) {
 	t.Run("Scan (Immutable)", func(t *testing.T) {
 		i := null.From[int](100)
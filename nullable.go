@@ -0,0 +1,23 @@
+//go:build !go1.24
+
+package null
+
+import (
+	"database/sql/driver"
+
+	// database/sql.Null is available since go1.22,
+	// but its Value method ignores the payload's driver.Valuer until go1.24.
+	// https://github.com/golang/go/issues/69728
+	sql1_22 "github.com/qawatake/null/internal/sql"
+)
+
+// nullable is the fork of database/sql.Null for toolchains older than go1.24.
+type nullable[V any] sql1_22.Null[V]
+
+func (n *nullable[V]) Scan(src any) error {
+	return (*sql1_22.Null[V])(n).Scan(src)
+}
+
+func (n nullable[V]) Value() (driver.Value, error) {
+	return sql1_22.Null[V](n).Value()
+}
@@ -0,0 +1,19 @@
+//go:build go1.24
+
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// nullable is database/sql.Null.
+type nullable[V any] sql.Null[V]
+
+func (n *nullable[V]) Scan(src any) error {
+	return (*sql.Null[V])(n).Scan(src)
+}
+
+func (n nullable[V]) Value() (driver.Value, error) {
+	return sql.Null[V](n).Value()
+}
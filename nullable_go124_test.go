@@ -0,0 +1,92 @@
+//go:build go1.24
+
+package null_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sql1_22 "github.com/qawatake/null/internal/sql"
+)
+
+// TestScan_Differential checks that the fork in internal/sql,
+// which is used on toolchains older than go1.24,
+// behaves the same as database/sql for every case in TestScan.
+func TestScan_Differential(t *testing.T) {
+	t.Run("Bool", func(t *testing.T) {
+		testScanDifferential(t, scanTestCasesBool)
+	})
+	t.Run("Float64", func(t *testing.T) {
+		testScanDifferential(t, scanTestCasesFloat64)
+	})
+	t.Run("Int64", func(t *testing.T) {
+		testScanDifferential(t, scanTestCasesInt64)
+	})
+	t.Run("String", func(t *testing.T) {
+		testScanDifferential(t, scanTestCasesString)
+	})
+	t.Run("Time", func(t *testing.T) {
+		testScanDifferential(t, scanTestCasesTime)
+	})
+	t.Run("CustomScanner", func(t *testing.T) {
+		testScanDifferential(t, scanTestCasesCustomScanner)
+	})
+}
+
+func testScanDifferential[V comparable](t *testing.T, tests []scanTestCase[V]) {
+	t.Helper()
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var std sql.Null[V]
+			var fork sql1_22.Null[V]
+			errStd := std.Scan(tt.src)
+			errFork := fork.Scan(tt.src)
+			assertSameError(t, errFork, errStd)
+			assertEqual(t, fork.V, std.V, cmp.AllowUnexported(customScanner{}))
+			assertEqual(t, fork.Valid, std.Valid)
+
+			vStd, errStd := std.Value()
+			vFork, errFork := fork.Value()
+			assertSameError(t, errFork, errStd)
+			assertEqual(t, vFork, vStd, cmp.AllowUnexported(customScanner{}))
+		})
+	}
+}
+
+// prefixValuer is a payload whose Value method does not return itself.
+type prefixValuer string
+
+func (v prefixValuer) Value() (driver.Value, error) {
+	if v == "" {
+		return nil, errors.New("empty")
+	}
+	return "v:" + string(v), nil
+}
+
+// TestValue_Differential checks that the fork calls the Value method of the payload
+// and converts the result as database/sql does since go1.24.
+func TestValue_Differential(t *testing.T) {
+	for _, v := range []prefixValuer{"a", ""} {
+		std := sql.Null[prefixValuer]{V: v, Valid: true}
+		fork := sql1_22.Null[prefixValuer]{V: v, Valid: true}
+		vStd, errStd := std.Value()
+		vFork, errFork := fork.Value()
+		assertSameError(t, errFork, errStd)
+		assertEqual(t, vFork, vStd)
+	}
+}
+
+func assertSameError(t *testing.T, x, y error) {
+	t.Helper()
+	if (x == nil) != (y == nil) {
+		t.Errorf("error mismatch: %v vs %v", x, y)
+		return
+	}
+	if x != nil {
+		assertEqual(t, x.Error(), y.Error())
+	}
+}
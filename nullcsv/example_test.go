@@ -0,0 +1,49 @@
+package nullcsv_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullcsv"
+)
+
+func ExampleEncoder() {
+	type User struct {
+		ID       int64          `csv:"id"`
+		Nickname null.T[string] `csv:"nickname"`
+	}
+
+	enc := nullcsv.NewEncoder[User](os.Stdout, nullcsv.PostgresText)
+	_ = enc.Encode(User{ID: 1, Nickname: null.From("ally")})
+	_ = enc.Encode(User{ID: 2})
+	_ = enc.Encode(User{ID: 3, Nickname: null.From(`\N`)})
+	_ = enc.Flush()
+	// Output:
+	// 1	ally
+	// 2	\N
+	// 3	\\N
+}
+
+func ExampleDecoder() {
+	type User struct {
+		ID       int64          `csv:"id"`
+		Nickname null.T[string] `csv:"nickname"`
+	}
+
+	in := "id,nickname\n1,\n2,\"\"\n"
+	f := nullcsv.PostgresCSV
+	f.Header = true
+	dec := nullcsv.NewDecoder[User](strings.NewReader(in), f)
+	for {
+		var u User
+		if err := dec.Decode(&u); err != nil {
+			break
+		}
+		fmt.Printf("%d: null=%v\n", u.ID, u.Nickname.IsNull())
+	}
+	// Output:
+	// 1: null=true
+	// 2: null=false
+}
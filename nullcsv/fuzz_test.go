@@ -0,0 +1,48 @@
+package nullcsv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullcsv"
+)
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, s := range []string{"", "NULL", `\N`, "a,b", "\"q\"", " lead", "x\r\ny", "\\", "\t"} {
+		f.Add(s, s, true)
+	}
+	formats := []nullcsv.Format{
+		nullcsv.PostgresText,
+		nullcsv.PostgresCSV,
+		{Mode: nullcsv.CSV, Comma: ';', Null: "NULL"},
+		{Mode: nullcsv.Text, Comma: ',', Null: "NULL"},
+		{Mode: nullcsv.Text, Comma: '|', Null: `\N`},
+	}
+	type row struct {
+		A null.T[string]
+		B null.T[string]
+		C null.T[string]
+	}
+	f.Fuzz(func(t *testing.T, a, b string, isNull bool) {
+		in := row{A: null.From(a), C: null.From(b)}
+		if !isNull {
+			in.B = null.From(a + b)
+		}
+		for _, format := range formats {
+			var buf bytes.Buffer
+			enc := nullcsv.NewEncoder[row](&buf, format)
+			requireNoError(t, enc.Encode(in))
+			requireNoError(t, enc.Flush())
+			text := buf.String()
+
+			var out row
+			if err := nullcsv.NewDecoder[row](&buf, format).Decode(&out); err != nil {
+				t.Fatalf("format: %+v, text: %q, err: %v", format, text, err)
+			}
+			if out != in {
+				t.Errorf("format: %+v, text: %q, got %+v, want %+v", format, text, out, in)
+			}
+		}
+	})
+}
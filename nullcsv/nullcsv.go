@@ -0,0 +1,528 @@
+// Package nullcsv encodes and decodes structs of nullable fields as CSV or as the text format
+// of PostgreSQL COPY and MySQL LOAD DATA, with a NULL token distinct from the empty string.
+//
+// Each exported field is a column named by its csv tag or, without a tag, by the field name.
+// Fields of embedded structs are promoted, and a field tagged csv:"-" is ignored.
+//
+// A field is encoded as the NULL token if it is a nil pointer or its Value method returns nil, as for null.T.
+// A payload which would read as the NULL token is escaped:
+// it is quoted in CSV and its first character is backslash-escaped in the text format.
+// A field is decoded by its Scan method if its pointer implements sql.Scanner, as for null.T,
+// and as database/sql converts a string column otherwise.
+// A time.Time or a null.T of it is decoded from RFC 3339 or from a timestamp as PostgreSQL COPY writes it,
+// such as 2024-03-10 12:04:05+09.
+// Decoding the NULL token into a field which cannot hold NULL fails.
+package nullcsv
+
+import (
+	"bufio"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/qawatake/null/internal/pgtime"
+	sql1_22 "github.com/qawatake/null/internal/sql"
+)
+
+// Mode is a way of escaping fields.
+type Mode int
+
+const (
+	// CSV quotes fields as in RFC 4180. The NULL token is recognized only if it is not quoted.
+	CSV Mode = iota
+	// Text escapes special characters with backslashes, as PostgreSQL COPY and MySQL LOAD DATA do by default.
+	Text
+)
+
+// Format describes a delimited format.
+type Format struct {
+	Mode  Mode
+	Comma rune
+	// Null is the token of NULL. It must not be empty in the Text mode.
+	Null string
+	// Header reports whether the first record is a header of column names.
+	Header bool
+}
+
+// Formats of well-known tools.
+var (
+	// PostgresText is the text format of PostgreSQL COPY.
+	PostgresText = Format{Mode: Text, Comma: '\t', Null: `\N`}
+	// PostgresCSV is the CSV format of PostgreSQL COPY, in which NULL is an unquoted empty field.
+	PostgresCSV = Format{Mode: CSV, Comma: ',', Null: ""}
+	// MySQL is the default format of MySQL LOAD DATA and SELECT ... INTO OUTFILE.
+	MySQL = Format{Mode: Text, Comma: '\t', Null: `\N`}
+)
+
+func (f Format) validate() error {
+	switch {
+	case f.Comma == 0 || f.Comma == '"' || f.Comma == '\\' || f.Comma == '\r' || f.Comma == '\n':
+		return fmt.Errorf("nullcsv: invalid delimiter %q", f.Comma)
+	case strings.ContainsRune(f.Null, f.Comma) || strings.ContainsAny(f.Null, "\r\n"):
+		return fmt.Errorf("nullcsv: NULL token %q contains a delimiter", f.Null)
+	case f.Mode == CSV && f.csvNeedsQuote(f.Null):
+		return fmt.Errorf("nullcsv: NULL token %q needs quoting", f.Null)
+	case f.Mode == Text && f.Null == "":
+		return errors.New("nullcsv: empty NULL token in the Text mode")
+	case f.Mode == Text && strings.IndexByte("nrtbfvx0123456789", f.Null[0]) >= 0:
+		// A payload equal to the token is escaped by a backslash before its first character,
+		// which must not form an escape sequence.
+		return fmt.Errorf("nullcsv: NULL token %q starts with an escape character", f.Null)
+	}
+	return nil
+}
+
+// Encoder writes structs of type S as records.
+type Encoder[S any] struct {
+	w       *bufio.Writer
+	f       Format
+	fields  []field
+	err     error
+	started bool
+}
+
+// NewEncoder returns an Encoder writing to w in the format f.
+func NewEncoder[S any](w io.Writer, f Format) *Encoder[S] {
+	e := &Encoder[S]{w: bufio.NewWriter(w), f: f}
+	if e.err = f.validate(); e.err == nil {
+		e.fields, e.err = fieldsOf(reflect.TypeOf((*S)(nil)).Elem())
+	}
+	return e
+}
+
+// Encode writes s as a record, preceded by the header for the first record if f.Header is true.
+// Records are buffered; call Flush to write them to the underlying writer.
+func (e *Encoder[S]) Encode(s S) error {
+	if e.err != nil {
+		return e.err
+	}
+	if !e.started && e.f.Header {
+		names := make([]string, len(e.fields))
+		for i, f := range e.fields {
+			names[i] = f.name
+		}
+		e.writeRecord(names, make([]bool, len(names)))
+	}
+	e.started = true
+
+	v := reflect.ValueOf(&s).Elem()
+	texts := make([]string, len(e.fields))
+	nulls := make([]bool, len(e.fields))
+	for i, f := range e.fields {
+		dv, err := driver.DefaultParameterConverter.ConvertValue(v.FieldByIndex(f.index).Interface())
+		if err != nil {
+			return fmt.Errorf("nullcsv: field %s: %w", f.name, err)
+		}
+		if dv == nil {
+			nulls[i] = true
+			continue
+		}
+		if texts[i], err = format(dv); err != nil {
+			return fmt.Errorf("nullcsv: field %s: %w", f.name, err)
+		}
+	}
+	e.writeRecord(texts, nulls)
+	return e.err
+}
+
+// Flush writes the buffered records to the underlying writer.
+func (e *Encoder[S]) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+func (e *Encoder[S]) writeRecord(texts []string, nulls []bool) {
+	for i, s := range texts {
+		if i > 0 {
+			e.w.WriteRune(e.f.Comma)
+		}
+		switch {
+		case nulls[i]:
+			e.w.WriteString(e.f.Null)
+		case e.f.Mode == Text:
+			s = e.f.escape(s)
+			if s == e.f.Null {
+				// A backslash followed by an ordinary character stands for the character.
+				e.w.WriteByte('\\')
+			}
+			e.w.WriteString(s)
+		case s == e.f.Null || e.f.csvNeedsQuote(s):
+			e.w.WriteByte('"')
+			e.w.WriteString(strings.ReplaceAll(s, `"`, `""`))
+			e.w.WriteByte('"')
+		default:
+			e.w.WriteString(s)
+		}
+	}
+	_, e.err = e.w.WriteString("\n")
+}
+
+func (f Format) csvNeedsQuote(s string) bool {
+	if s == "" {
+		return false
+	}
+	return strings.ContainsRune(s, f.Comma) || strings.ContainsAny(s, "\"\r\n") || s[0] == ' ' || s[0] == '\t'
+}
+
+// escape escapes s for the Text mode.
+func (f Format) escape(s string) string {
+	// Iterate over bytes rather than runes to keep invalid UTF-8 as it is.
+	comma := string(f.Comma)
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			b.WriteString(`\\`)
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case strings.HasPrefix(s[i:], comma):
+			b.WriteByte('\\')
+			b.WriteString(comma)
+			i += len(comma) - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// format returns the text of a driver.Value.
+func format(v driver.Value) (string, error) {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// Decoder reads records into structs of type S.
+type Decoder[S any] struct {
+	r      *bufio.Reader
+	f      Format
+	fields []field
+	line   int
+	err    error
+	// columns maps the columns of records to fields. It is set by the header or to fields.
+	columns []field
+}
+
+// NewDecoder returns a Decoder reading from r in the format f.
+// If f.Header is true, columns are mapped to fields by the header; otherwise, by the order of the fields.
+func NewDecoder[S any](r io.Reader, f Format) *Decoder[S] {
+	d := &Decoder[S]{r: bufio.NewReader(r), f: f}
+	if d.err = f.validate(); d.err == nil {
+		d.fields, d.err = fieldsOf(reflect.TypeOf((*S)(nil)).Elem())
+	}
+	return d
+}
+
+// Decode reads the next record into s. It returns io.EOF when there are no more records.
+func (d *Decoder[S]) Decode(s *S) error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.columns == nil {
+		if err := d.readHeader(); err != nil {
+			return err
+		}
+	}
+	rec, err := d.readRecord()
+	if err != nil {
+		return err
+	}
+	if len(rec) != len(d.columns) {
+		return fmt.Errorf("nullcsv: line %d: %d fields, want %d", d.line, len(rec), len(d.columns))
+	}
+	v := reflect.ValueOf(s).Elem()
+	for i, f := range d.columns {
+		if err := f.set(v.FieldByIndex(f.index), rec[i]); err != nil {
+			return fmt.Errorf("nullcsv: line %d: %w", d.line, err)
+		}
+	}
+	return nil
+}
+
+func (d *Decoder[S]) readHeader() error {
+	if !d.f.Header {
+		d.columns = d.fields
+		return nil
+	}
+	rec, err := d.readRecord()
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]field, len(d.fields))
+	for _, f := range d.fields {
+		byName[f.name] = f
+	}
+	columns := make([]field, len(rec))
+	for i, c := range rec {
+		f, ok := byName[c.text]
+		if c.null || !ok {
+			d.err = fmt.Errorf("nullcsv: line %d: no field for column %q", d.line, c.text)
+			return d.err
+		}
+		columns[i] = f
+	}
+	d.columns = columns
+	return nil
+}
+
+// cell is a field of a record.
+type cell struct {
+	text string
+	null bool
+}
+
+func (d *Decoder[S]) readRecord() ([]cell, error) {
+	d.line++
+	if d.f.Mode == Text {
+		return d.readText()
+	}
+	return d.readCSV()
+}
+
+func (d *Decoder[S]) readText() ([]cell, error) {
+	line, err := d.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A carriage return in a payload is escaped, so one ending the line is part of a CRLF terminator.
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+	var rec []cell
+	var b strings.Builder
+	start := 0
+	for i := 0; ; {
+		if i == len(line) || strings.HasPrefix(line[i:], string(d.f.Comma)) {
+			raw := line[start:i]
+			if raw == d.f.Null {
+				rec = append(rec, cell{null: true})
+			} else {
+				rec = append(rec, cell{text: b.String()})
+			}
+			b.Reset()
+			if i == len(line) {
+				return rec, nil
+			}
+			i += len(string(d.f.Comma))
+			start = i
+			continue
+		}
+		c := line[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if i+1 == len(line) {
+			return nil, fmt.Errorf("nullcsv: line %d: unexpected end after backslash", d.line)
+		}
+		switch c := line[i+1]; c {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'v':
+			b.WriteByte('\v')
+		default:
+			b.WriteByte(c)
+		}
+		i += 2
+	}
+}
+
+func (d *Decoder[S]) readCSV() ([]cell, error) {
+	var rec []cell
+	var b strings.Builder
+	quoted, inQuote, read := false, false, false
+	for {
+		r, size, err := d.r.ReadRune()
+		if r == utf8.RuneError && size == 1 {
+			// Keep invalid UTF-8 as it is.
+			_ = d.r.UnreadRune()
+			c, _ := d.r.ReadByte()
+			b.WriteByte(c)
+			read = true
+			continue
+		}
+		if err == io.EOF {
+			if inQuote {
+				return nil, fmt.Errorf("nullcsv: line %d: unterminated quote", d.line)
+			}
+			if !read {
+				return nil, io.EOF
+			}
+			r, err = '\n', nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		read = true
+		if r == '\r' && !inQuote {
+			if next, _, err := d.r.ReadRune(); err == nil && next == '\n' {
+				r = '\n'
+			} else if err == nil {
+				_ = d.r.UnreadRune()
+			}
+		}
+		switch {
+		case inQuote && r == '"':
+			if next, _, err := d.r.ReadRune(); err == nil && next == '"' {
+				b.WriteByte('"')
+			} else {
+				if err == nil {
+					_ = d.r.UnreadRune()
+				}
+				inQuote = false
+			}
+		case inQuote:
+			if r == '\n' {
+				d.line++
+			}
+			b.WriteRune(r)
+		case r == '"' && b.Len() == 0 && !quoted:
+			inQuote, quoted = true, true
+		case r == d.f.Comma || r == '\n':
+			text := b.String()
+			rec = append(rec, cell{text: text, null: !quoted && text == d.f.Null})
+			if r == '\n' {
+				return rec, nil
+			}
+			b.Reset()
+			quoted = false
+		case quoted:
+			return nil, fmt.Errorf("nullcsv: line %d: unexpected %q after a quoted field", d.line, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+// field is a column of a struct type.
+type field struct {
+	name    string
+	index   []int
+	typ     reflect.Type
+	scanner bool
+	time    bool
+}
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+func fieldsOf(t reflect.Type) ([]field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullcsv: %v is not a struct type", t)
+	}
+	var fields []field
+	collect(t, nil, &fields)
+	return fields, nil
+}
+
+func collect(t reflect.Type, index []int, fields *[]field) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		idx := append(index[:len(index):len(index)], i)
+		pt := reflect.PointerTo(f.Type)
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct && !pt.Implements(scannerType) && !f.Type.Implements(valuerType) {
+			collect(f.Type, idx, fields)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = f.Name
+		}
+		*fields = append(*fields, field{
+			name:    name,
+			index:   idx,
+			typ:     f.Type,
+			scanner: pt.Implements(scannerType),
+			time:    f.Type == timeType || payloadType(f.Type) == timeType,
+		})
+	}
+}
+
+// payloadType returns the payload type of a nullable type such as null.T[V], or nil.
+func payloadType(t reflect.Type) reflect.Type {
+	if m, ok := t.MethodByName("ValueOrZero"); ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 {
+		return m.Type.Out(0)
+	}
+	return nil
+}
+
+// set decodes c into v.
+func (f field) set(v reflect.Value, c cell) error {
+	if c.null {
+		switch {
+		case f.scanner:
+			return v.Addr().Interface().(sql.Scanner).Scan(nil)
+		case f.typ.Kind() == reflect.Pointer:
+			v.SetZero()
+			return nil
+		default:
+			return fmt.Errorf("column %q is NULL but field of type %v is not nullable", f.name, f.typ)
+		}
+	}
+	var src any = c.text
+	if f.time {
+		t, err := pgtime.Parse(c.text)
+		if err != nil {
+			return fmt.Errorf("column %q: %w", f.name, err)
+		}
+		src = t
+	}
+	var err error
+	if f.scanner {
+		err = v.Addr().Interface().(sql.Scanner).Scan(src)
+	} else {
+		err = sql1_22.ConvertAssign(v.Addr().Interface(), src)
+	}
+	if err != nil {
+		return fmt.Errorf("column %q: %w", f.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package nullcsv_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullcsv"
+)
+
+type Meta struct {
+	Note null.T[string] `csv:"note"`
+}
+
+type Record struct {
+	ID      int64             `csv:"id"`
+	Name    null.T[string]    `csv:"name"`
+	Score   null.T[float64]   `csv:"score"`
+	Active  null.T[bool]      `csv:"active"`
+	At      null.T[time.Time] `csv:"at"`
+	Comment *string           `csv:"comment"`
+	Ignored string            `csv:"-"`
+	Meta
+}
+
+var (
+	at      = time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	records = []Record{
+		{ID: 1, Name: null.From("alice"), Score: null.From(1.5), Active: null.From(true), At: null.From(at), Comment: toptr("hi"), Meta: Meta{Note: null.From("a,b")}},
+		{ID: 2},
+		{ID: 3, Name: null.From(""), Comment: toptr(""), Meta: Meta{Note: null.From("line1\nline2\t\"q\" \\")}},
+		{ID: 4, Name: null.From(`\N`), Meta: Meta{Note: null.From("NULL")}},
+	}
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		format nullcsv.Format
+		want   string
+	}{
+		{
+			name:   "PostgresText",
+			format: nullcsv.PostgresText,
+			want: "1\talice\t1.5\ttrue\t2024-01-02T03:04:05.0000006Z\thi\ta,b\n" +
+				"2\t\\N\t\\N\t\\N\t\\N\t\\N\t\\N\n" +
+				"3\t\t\\N\t\\N\t\\N\t\t" + `line1\nline2\t"q" \\` + "\n" +
+				"4\t" + `\\N` + "\t\\N\t\\N\t\\N\t\\N\tNULL\n",
+		},
+		{
+			name:   "PostgresCSV",
+			format: nullcsv.PostgresCSV,
+			want: "1,alice,1.5,true,2024-01-02T03:04:05.0000006Z,hi,\"a,b\"\n" +
+				"2,,,,,,\n" +
+				"3,\"\",,,,\"\",\"line1\nline2\t\"\"q\"\" \\\"\n" +
+				"4,\\N,,,,,NULL\n",
+		},
+		{
+			name:   "CSV with NULL token and header",
+			format: nullcsv.Format{Mode: nullcsv.CSV, Comma: ';', Null: "NULL", Header: true},
+			want: "id;name;score;active;at;comment;note\n" +
+				"1;alice;1.5;true;2024-01-02T03:04:05.0000006Z;hi;a,b\n" +
+				"2;NULL;NULL;NULL;NULL;NULL;NULL\n" +
+				"3;;NULL;NULL;NULL;;\"line1\nline2\t\"\"q\"\" \\\"\n" +
+				"4;\\N;NULL;NULL;NULL;NULL;\"NULL\"\n",
+		},
+		{
+			name:   "Text with NULL token",
+			format: nullcsv.Format{Mode: nullcsv.Text, Comma: ',', Null: "NULL"},
+			want: "1,alice,1.5,true,2024-01-02T03:04:05.0000006Z,hi,a\\,b\n" +
+				"2,NULL,NULL,NULL,NULL,NULL,NULL\n" +
+				"3,,NULL,NULL,NULL,," + `line1\nline2\t"q" \\` + "\n" +
+				"4," + `\\N` + ",NULL,NULL,NULL,NULL,\\NULL\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := nullcsv.NewEncoder[Record](&buf, tt.format)
+			for _, r := range records {
+				r.Ignored = "ignored"
+				requireNoError(t, enc.Encode(r))
+			}
+			requireNoError(t, enc.Flush())
+			assertEqual(t, buf.String(), tt.want)
+
+			dec := nullcsv.NewDecoder[Record](&buf, tt.format)
+			var got []Record
+			for {
+				var r Record
+				err := dec.Decode(&r)
+				if err == io.EOF {
+					break
+				}
+				requireNoError(t, err)
+				got = append(got, r)
+			}
+			assertEqual(t, got, records)
+		})
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	t.Run("header order and CRLF", func(t *testing.T) {
+		in := "note,id,name\r\n\"x\",1,\r\n,2,\"\"\r\n"
+		f := nullcsv.PostgresCSV
+		f.Header = true
+		dec := nullcsv.NewDecoder[Record](strings.NewReader(in), f)
+		var r Record
+		requireNoError(t, dec.Decode(&r))
+		assertEqual(t, r, Record{ID: 1, Meta: Meta{Note: null.From("x")}})
+		r = Record{}
+		requireNoError(t, dec.Decode(&r))
+		assertEqual(t, r, Record{ID: 2, Name: null.From("")})
+		assertEqual(t, dec.Decode(&r) == io.EOF, true)
+	})
+
+	t.Run("no trailing newline", func(t *testing.T) {
+		dec := nullcsv.NewDecoder[struct{ A, B null.T[int] }](strings.NewReader("1\t\\N"), nullcsv.PostgresText)
+		var r struct{ A, B null.T[int] }
+		requireNoError(t, dec.Decode(&r))
+		assertEqual(t, r.A, null.From(1))
+		assertEqual(t, r.B.IsNull(), true)
+		assertEqual(t, dec.Decode(&r) == io.EOF, true)
+	})
+
+	t.Run("text with CRLF", func(t *testing.T) {
+		type row struct {
+			A null.T[int]
+			B null.T[string]
+		}
+		dec := nullcsv.NewDecoder[row](strings.NewReader("1\tx\r\n2\t\\N\r\n3\ty\\r\r\n"), nullcsv.PostgresText)
+		var got []row
+		for {
+			var r row
+			err := dec.Decode(&r)
+			if err == io.EOF {
+				break
+			}
+			requireNoError(t, err)
+			got = append(got, r)
+		}
+		assertEqual(t, got, []row{{null.From(1), null.From("x")}, {A: null.From(2)}, {null.From(3), null.From("y\r")}})
+	})
+
+	t.Run("PostgreSQL timestamps", func(t *testing.T) {
+		type row struct {
+			At    time.Time
+			NotAt null.T[time.Time]
+		}
+		jst := time.FixedZone("", 9*60*60)
+		in := "2024-03-10 12:04:05+09\t2024-03-10 12:04:05.123456\n" +
+			"2024-03-10T12:04:05+09:00\t\\N\n"
+		dec := nullcsv.NewDecoder[row](strings.NewReader(in), nullcsv.PostgresText)
+		var r row
+		requireNoError(t, dec.Decode(&r))
+		assertEqual(t, r.At, time.Date(2024, 3, 10, 12, 4, 5, 0, jst))
+		assertEqual(t, r.NotAt, null.From(time.Date(2024, 3, 10, 12, 4, 5, 123456000, time.UTC)))
+		r = row{}
+		requireNoError(t, dec.Decode(&r))
+		assertEqual(t, r.At, time.Date(2024, 3, 10, 12, 4, 5, 0, jst))
+		assertEqual(t, r.NotAt.IsNull(), true)
+	})
+}
+
+func TestDecoder_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		format nullcsv.Format
+	}{
+		{name: "NULL into non-nullable", in: "\\N\tx\t\\N\t\\N\t\\N\t\\N\t\\N\n", format: nullcsv.PostgresText},
+		{name: "conversion", in: "x,,,,,,\n", format: nullcsv.PostgresCSV},
+		{name: "time", in: "1,,,,yesterday,,\n", format: nullcsv.PostgresCSV},
+		{name: "field count", in: "1,2\n", format: nullcsv.PostgresCSV},
+		{name: "unterminated quote", in: "1,\"a\n", format: nullcsv.PostgresCSV},
+		{name: "text after quote", in: "1,\"a\"b,,,,,\n", format: nullcsv.PostgresCSV},
+		{name: "trailing backslash", in: "1\t\\", format: nullcsv.PostgresText},
+		{name: "unknown column", in: "id,unknown\n", format: nullcsv.Format{Mode: nullcsv.CSV, Comma: ',', Null: "", Header: true}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var r Record
+			err := nullcsv.NewDecoder[Record](strings.NewReader(tt.in), tt.format).Decode(&r)
+			requireError(t, err)
+			if errors.Is(err, io.EOF) {
+				t.Errorf("want a decoding error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFormat_Invalid(t *testing.T) {
+	for _, f := range []nullcsv.Format{
+		{Mode: nullcsv.CSV},
+		{Mode: nullcsv.CSV, Comma: '"'},
+		{Mode: nullcsv.CSV, Comma: ',', Null: "a,b"},
+		{Mode: nullcsv.CSV, Comma: ',', Null: " NULL"},
+		{Mode: nullcsv.Text, Comma: '\t'},
+		{Mode: nullcsv.Text, Comma: '\t', Null: "null"},
+		{Mode: nullcsv.Text, Comma: '\t', Null: "\n"},
+	} {
+		requireError(t, nullcsv.NewEncoder[Record](io.Discard, f).Encode(Record{}))
+		var r Record
+		requireError(t, nullcsv.NewDecoder[Record](strings.NewReader(""), f).Decode(&r))
+	}
+	requireError(t, nullcsv.NewEncoder[int](io.Discard, nullcsv.PostgresCSV).Encode(1))
+}
+
+func toptr[T any](v T) *T {
+	return &v
+}
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
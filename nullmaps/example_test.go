@@ -0,0 +1,23 @@
+package nullmaps_test
+
+import (
+	"fmt"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullmaps"
+)
+
+func ExampleLookup() {
+	prices := map[string]int{"apple": 100, "free sample": 0}
+	fmt.Println(nullmaps.Lookup(prices, "apple"), nullmaps.Lookup(prices, "free sample"), nullmaps.Lookup(prices, "melon"))
+	// Output:
+	// 100 0 null
+}
+
+func ExampleFill() {
+	// An unset timeout means no limit.
+	timeouts := map[string]null.T[int]{"read": null.From(30), "write": {}}
+	fmt.Println(nullmaps.Fill(timeouts, 0))
+	// Output:
+	// map[read:30 write:0]
+}
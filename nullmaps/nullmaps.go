@@ -0,0 +1,115 @@
+// Package nullmaps provides functions for maps of null.T, in the manner of the maps package.
+//
+// Functions returning a new map return nil if the input map is nil.
+package nullmaps
+
+import "github.com/qawatake/null"
+
+// Lookup returns the value for k in m, or null if m has no such key.
+// It converts the comma-ok idiom v, ok := m[k] to a null.T.
+func Lookup[M ~map[K]V, K, V comparable](m M, k K) null.T[V] {
+	v, ok := m[k]
+	if !ok {
+		return null.T[V]{}
+	}
+	return null.From(v)
+}
+
+// CountNull returns the number of null values in m.
+func CountNull[M ~map[K]null.T[V], K, V comparable](m M) int {
+	n := 0
+	for _, t := range m {
+		if t.IsNull() {
+			n++
+		}
+	}
+	return n
+}
+
+// Payloads returns a new map holding the payloads of the non-null values of m.
+func Payloads[M ~map[K]null.T[V], K, V comparable](m M) map[K]V {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]V, len(m)-CountNull(m))
+	for k, t := range m {
+		if v, ok := t.Get(); ok {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// Partition returns a new map holding the payloads of the non-null values of m
+// and the keys of the null values in an unspecified order.
+func Partition[M ~map[K]null.T[V], K, V comparable](m M) (values map[K]V, nulls []K) {
+	if m == nil {
+		return nil, nil
+	}
+	values = make(map[K]V, len(m))
+	for k, t := range m {
+		if v, ok := t.Get(); ok {
+			values[k] = v
+		} else {
+			nulls = append(nulls, k)
+		}
+	}
+	return values, nulls
+}
+
+// Fill returns a new map holding the payloads of m, with v in place of each null value.
+func Fill[M ~map[K]null.T[V], K, V comparable](m M, v V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]V, len(m))
+	for k, t := range m {
+		if p, ok := t.Get(); ok {
+			r[k] = p
+		} else {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// FromPtrs returns a new map holding null for each nil value of m and the pointed value otherwise.
+func FromPtrs[M ~map[K]*V, K, V comparable](m M) map[K]null.T[V] {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]null.T[V], len(m))
+	for k, p := range m {
+		r[k] = null.FromPtr(p)
+	}
+	return r
+}
+
+// ToPtrs returns a new map holding nil for each null value of m and a pointer to its payload otherwise.
+func ToPtrs[M ~map[K]null.T[V], K, V comparable](m M) map[K]*V {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]*V, len(m))
+	for k, t := range m {
+		r[k] = t.Ptr()
+	}
+	return r
+}
+
+// FromValues returns a new map holding null for each value v of m for which isNull(v) is true
+// and v otherwise.
+func FromValues[M ~map[K]V, K, V comparable](m M, isNull func(V) bool) map[K]null.T[V] {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]null.T[V], len(m))
+	for k, v := range m {
+		if isNull(v) {
+			r[k] = null.T[V]{}
+		} else {
+			r[k] = null.From(v)
+		}
+	}
+	return r
+}
@@ -0,0 +1,78 @@
+package nullmaps_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullmaps"
+)
+
+type scores map[string]null.T[int]
+
+func newScores() scores {
+	return scores{"alice": null.From(80), "bob": {}, "carol": null.From(0), "dave": {}}
+}
+
+func TestLookup(t *testing.T) {
+	m := map[string]int{"a": 1, "z": 0}
+	assertEqual(t, nullmaps.Lookup(m, "a"), null.From(1))
+	assertEqual(t, nullmaps.Lookup(m, "z"), null.From(0))
+	assertEqual(t, nullmaps.Lookup(m, "b"), null.T[int]{})
+	assertEqual(t, nullmaps.Lookup(map[string]int(nil), "a"), null.T[int]{})
+}
+
+func TestCountNull(t *testing.T) {
+	assertEqual(t, nullmaps.CountNull(newScores()), 2)
+	assertEqual(t, nullmaps.CountNull(scores(nil)), 0)
+}
+
+func TestPayloads(t *testing.T) {
+	assertEqual(t, nullmaps.Payloads(newScores()), map[string]int{"alice": 80, "carol": 0})
+	assertEqual(t, nullmaps.Payloads(scores{"x": {}}), map[string]int{})
+	assertEqual(t, nullmaps.Payloads(scores(nil)), map[string]int(nil))
+}
+
+func TestPartition(t *testing.T) {
+	values, nulls := nullmaps.Partition(newScores())
+	sort.Strings(nulls)
+	assertEqual(t, values, map[string]int{"alice": 80, "carol": 0})
+	assertEqual(t, nulls, []string{"bob", "dave"})
+
+	values, nulls = nullmaps.Partition(scores(nil))
+	assertEqual(t, values, map[string]int(nil))
+	assertEqual(t, nulls, []string(nil))
+}
+
+func TestFill(t *testing.T) {
+	m := newScores()
+	assertEqual(t, nullmaps.Fill(m, -1), map[string]int{"alice": 80, "bob": -1, "carol": 0, "dave": -1})
+	assertEqual(t, m, newScores())
+	assertEqual(t, nullmaps.Fill(scores(nil), -1), map[string]int(nil))
+}
+
+func TestPtrs(t *testing.T) {
+	ps := nullmaps.ToPtrs(newScores())
+	assertEqual(t, len(ps), 4)
+	assertEqual(t, *ps["alice"], 80)
+	assertEqual(t, ps["bob"], (*int)(nil))
+	assertEqual(t, nullmaps.FromPtrs(ps), map[string]null.T[int](newScores()))
+	assertEqual(t, nullmaps.ToPtrs(scores(nil)), map[string]*int(nil))
+	assertEqual(t, nullmaps.FromPtrs(map[string]*int(nil)), map[string]null.T[int](nil))
+}
+
+func TestFromValues(t *testing.T) {
+	got := nullmaps.FromValues(map[string]int{"a": 1, "b": -1}, func(v int) bool { return v < 0 })
+	assertEqual(t, got, map[string]null.T[int]{"a": null.From(1), "b": {}})
+	assertEqual(t, nullmaps.FromValues(map[string]int(nil), func(int) bool { return true }), map[string]null.T[int](nil))
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+package nullrapid_test
+
+import (
+	"fmt"
+
+	"github.com/qawatake/null/nullrapid"
+	"pgregory.net/rapid"
+)
+
+func ExampleT() {
+	gen := nullrapid.T(rapid.IntRange(1, 100), 0.25)
+	for i := 0; i < 8; i++ {
+		fmt.Println(gen.Example(i))
+	}
+	// Output:
+	// 5
+	// null
+	// 44
+	// 1
+	// 59
+	// 18
+	// 4
+	// 2
+}
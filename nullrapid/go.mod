@@ -0,0 +1,13 @@
+module github.com/qawatake/null/nullrapid
+
+go 1.23
+
+require (
+	github.com/qawatake/null v0.5.0
+	pgregory.net/rapid v1.3.0
+)
+
+require (
+	github.com/google/go-cmp v0.5.9 // indirect
+	gopkg.in/guregu/null.v4 v4.0.0 // indirect
+)
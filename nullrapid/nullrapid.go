@@ -0,0 +1,63 @@
+// Package nullrapid provides generators of null.T for property-based testing with [rapid].
+//
+// nullrapid is a separate module so that the null module does not depend on rapid.
+//
+// [rapid]: https://pkg.go.dev/pgregory.net/rapid
+package nullrapid
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/qawatake/null"
+	"pgregory.net/rapid"
+)
+
+// T returns a generator of null.T[V] which is null with probability nullProb
+// and otherwise holds a value drawn from payload.
+// A nullProb strictly between 0 and 1 is rounded to a multiple of 1/65536 strictly between 0 and 1.
+// It panics unless 0 <= nullProb <= 1.
+//
+// When a test fails, rapid shrinks the generated values toward null first,
+// and then, if the test passes for null, toward the minimal value of payload.
+func T[V comparable](payload *rapid.Generator[V], nullProb float64) *rapid.Generator[null.T[V]] {
+	if !(nullProb >= 0 && nullProb <= 1) {
+		panic(fmt.Sprintf("nullrapid: null probability %v is not in [0, 1]", nullProb))
+	}
+	switch nullProb {
+	case 0:
+		return rapid.Map(payload, null.From[V])
+	case 1:
+		return rapid.Just(null.T[V]{})
+	}
+	isNull := coin(nullProb)
+	return rapid.Custom(func(t *rapid.T) null.T[V] {
+		if isNull.Draw(t, "null") {
+			return null.T[V]{}
+		}
+		return null.From(payload.Draw(t, "payload"))
+	})
+}
+
+// bits is the number of random bits compared with the null probability.
+const bits = 16
+
+// coin returns a generator of booleans which are true with probability p, where 0 < p < 1,
+// rounded to a multiple of 1/2^bits.
+// It reports whether u < p for a uniform random u in [0, 1) drawn as a fixed number of bits.
+// Unlike integers and floats, booleans are generated uniformly by rapid and shrunk toward false,
+// so u shrinks toward 0 and the result toward true.
+func coin(p float64) *rapid.Generator[bool] {
+	threshold := uint32(math.Round(p * (1 << bits)))
+	threshold = max(1, min(threshold, 1<<bits-1))
+	return rapid.Custom(func(t *rapid.T) bool {
+		var u uint32
+		for i := 0; i < bits; i++ {
+			u <<= 1
+			if rapid.Bool().Draw(t, "bit") {
+				u |= 1
+			}
+		}
+		return u < threshold
+	})
+}
@@ -0,0 +1,123 @@
+package nullrapid_test
+
+import (
+	"flag"
+	"math"
+	"testing"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullrapid"
+	"pgregory.net/rapid"
+)
+
+func TestT(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		x := nullrapid.T(rapid.IntRange(1, 9), 0.5).Draw(t, "x")
+		if !x.IsNull() && (x.ValueOrZero() < 1 || x.ValueOrZero() > 9) {
+			t.Fatalf("payload %v out of range", x)
+		}
+	})
+}
+
+func TestT_Probability(t *testing.T) {
+	tests := []struct {
+		p        float64
+		min, max int
+	}{
+		{p: 0, min: 0, max: 0},
+		{p: 0.1, min: 60, max: 140},
+		{p: 0.5, min: 430, max: 570},
+		{p: 0.9, min: 860, max: 940},
+		{p: 1, min: 1000, max: 1000},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run("", func(t *testing.T) {
+			g := nullrapid.T(rapid.Int(), tt.p)
+			nulls := 0
+			for seed := 0; seed < 1000; seed++ {
+				if g.Example(seed).IsNull() {
+					nulls++
+				}
+			}
+			if nulls < tt.min || nulls > tt.max {
+				t.Errorf("p = %v: got %d nulls out of 1000, want [%d, %d]", tt.p, nulls, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestT_InvalidProbability(t *testing.T) {
+	for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("p = %v: want panic", p)
+				}
+			}()
+			nullrapid.T(rapid.Int(), p)
+		}()
+	}
+}
+
+func TestT_Shrink(t *testing.T) {
+	requireNoError(t, flag.Set("rapid.nofailfile", "true"))
+
+	tests := []struct {
+		name string
+		p    float64
+		fail func(x null.T[int]) bool
+		want null.T[int]
+	}{
+		{name: "toward null", p: 0.2, fail: func(x null.T[int]) bool { return true }, want: null.T[int]{}},
+		{name: "toward null with low probability", p: 0.01, fail: func(x null.T[int]) bool { return x.ValueOrZero() < 5 }, want: null.T[int]{}},
+		{name: "then toward minimal payload", p: 0.5, fail: func(x null.T[int]) bool { return !x.IsNull() && x.ValueOrZero() >= 10 }, want: null.From(10)},
+		{name: "minimal payload", p: 0.5, fail: func(x null.T[int]) bool { return !x.IsNull() }, want: null.From(0)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var last null.T[int]
+			r := &recorder{TB: t}
+			rapid.Check(r, func(rt *rapid.T) {
+				x := nullrapid.T(rapid.IntRange(0, 1000), tt.p).Draw(rt, "x")
+				if tt.fail(x) {
+					last = x
+					rt.Fatal()
+				}
+			})
+			if !r.failed {
+				t.Fatal("want failure, but got none")
+			}
+			// rapid replays the minimal failing example last.
+			if last != tt.want {
+				t.Errorf("got %v, want %v", last, tt.want)
+			}
+		})
+	}
+}
+
+// recorder records the failure of a rapid check instead of failing the test.
+type recorder struct {
+	rapid.TB
+	failed bool
+}
+
+func (r *recorder) Logf(string, ...any)   {}
+func (r *recorder) Log(...any)            {}
+func (r *recorder) Errorf(string, ...any) { r.failed = true }
+func (r *recorder) Error(...any)          { r.failed = true }
+func (r *recorder) Fatalf(string, ...any) { r.failed = true }
+func (r *recorder) Fatal(...any)          { r.failed = true }
+func (r *recorder) Fail()                 { r.failed = true }
+func (r *recorder) FailNow()              { r.failed = true }
+func (r *recorder) Failed() bool          { return r.failed }
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
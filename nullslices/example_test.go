@@ -0,0 +1,25 @@
+package nullslices_test
+
+import (
+	"fmt"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullslices"
+)
+
+func ExamplePayloads() {
+	scores := []null.T[int]{null.From(80), {}, null.From(65)}
+	fmt.Println(nullslices.Payloads(scores), nullslices.CountNull(scores))
+	// Output:
+	// [80 65] 1
+}
+
+func ExampleFromValues() {
+	// -1 means unknown.
+	ages := nullslices.FromValues([]int{30, -1, 0}, func(age int) bool { return age < 0 })
+	fmt.Println(ages)
+	fmt.Println(nullslices.Fill(ages, -1))
+	// Output:
+	// [30 null 0]
+	// [30 -1 0]
+}
@@ -0,0 +1,110 @@
+// Package nullslices provides functions for slices of null.T, in the manner of the slices package.
+//
+// Functions returning a new slice return nil if the input slice is nil.
+// Slices of the Null types of database/sql are converted by convert.Slice:
+//
+//	ts := convert.Slice(ns, convert.FromSQLNull[int64]) // []sql.Null[int64] -> []null.T[int64]
+package nullslices
+
+import "github.com/qawatake/null"
+
+// CountNull returns the number of null elements in s.
+func CountNull[S ~[]null.T[V], V comparable](s S) int {
+	n := 0
+	for _, t := range s {
+		if t.IsNull() {
+			n++
+		}
+	}
+	return n
+}
+
+// Payloads returns a new slice holding the payloads of the non-null elements of s in order.
+// Unlike null.Compact, which iterates over them, it collects them into a slice.
+func Payloads[S ~[]null.T[V], V comparable](s S) []V {
+	if s == nil {
+		return nil
+	}
+	vs := make([]V, 0, len(s)-CountNull(s))
+	for _, t := range s {
+		if v, ok := t.Get(); ok {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// Partition returns the payloads of the non-null elements of s in order
+// and the indices of the null elements in increasing order.
+func Partition[S ~[]null.T[V], V comparable](s S) (values []V, nulls []int) {
+	for i, t := range s {
+		if v, ok := t.Get(); ok {
+			values = append(values, v)
+		} else {
+			nulls = append(nulls, i)
+		}
+	}
+	return values, nulls
+}
+
+// Fill returns a new slice holding the payloads of s, with v in place of each null element.
+func Fill[S ~[]null.T[V], V comparable](s S, v V) []V {
+	if s == nil {
+		return nil
+	}
+	vs := make([]V, len(s))
+	for i, t := range s {
+		if p, ok := t.Get(); ok {
+			vs[i] = p
+		} else {
+			vs[i] = v
+		}
+	}
+	return vs
+}
+
+// FromPtrs returns a new slice holding null for each nil element of ps and the pointed value otherwise.
+func FromPtrs[V comparable](ps []*V) []null.T[V] {
+	if ps == nil {
+		return nil
+	}
+	s := make([]null.T[V], len(ps))
+	for i, p := range ps {
+		s[i] = null.FromPtr(p)
+	}
+	return s
+}
+
+// ToPtrs returns a new slice holding nil for each null element of s and a pointer to its payload otherwise.
+// The pointers point into a single array allocated for the result, which does not share memory with s.
+func ToPtrs[S ~[]null.T[V], V comparable](s S) []*V {
+	if s == nil {
+		return nil
+	}
+	ps := make([]*V, len(s))
+	vs := make([]V, len(s))
+	for i, t := range s {
+		if v, ok := t.Get(); ok {
+			vs[i] = v
+			ps[i] = &vs[i]
+		}
+	}
+	return ps
+}
+
+// FromValues returns a new slice holding null for each element v of vs for which isNull(v) is true
+// and v otherwise, converting a slice with sentinel values such as -1 or "":
+//
+//	ts := nullslices.FromValues(ages, func(age int) bool { return age < 0 })
+func FromValues[V comparable](vs []V, isNull func(V) bool) []null.T[V] {
+	if vs == nil {
+		return nil
+	}
+	s := make([]null.T[V], len(vs))
+	for i, v := range vs {
+		if !isNull(v) {
+			s[i] = null.From(v)
+		}
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package nullslices_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nullslices"
+)
+
+type ints []null.T[int]
+
+var s = ints{null.From(1), {}, null.From(0), {}, null.From(3)}
+
+func TestCountNull(t *testing.T) {
+	assertEqual(t, nullslices.CountNull(s), 2)
+	assertEqual(t, nullslices.CountNull(ints(nil)), 0)
+}
+
+func TestPayloads(t *testing.T) {
+	assertEqual(t, nullslices.Payloads(s), []int{1, 0, 3})
+	assertEqual(t, nullslices.Payloads(ints{{}}), []int{})
+	assertEqual(t, nullslices.Payloads(ints(nil)), []int(nil))
+}
+
+func TestPartition(t *testing.T) {
+	values, nulls := nullslices.Partition(s)
+	assertEqual(t, values, []int{1, 0, 3})
+	assertEqual(t, nulls, []int{1, 3})
+
+	values, nulls = nullslices.Partition(ints{null.From(2)})
+	assertEqual(t, values, []int{2})
+	assertEqual(t, nulls, []int(nil))
+}
+
+func TestFill(t *testing.T) {
+	assertEqual(t, nullslices.Fill(s, -1), []int{1, -1, 0, -1, 3})
+	assertEqual(t, nullslices.Fill(ints(nil), -1), []int(nil))
+}
+
+func TestPtrs(t *testing.T) {
+	ps := nullslices.ToPtrs(s)
+	assertEqual(t, len(ps), len(s))
+	for i, p := range ps {
+		assertEqual(t, null.FromPtr(p), s[i])
+	}
+	// The pointers are not shared with s.
+	*ps[0] = 100
+	assertEqual(t, s[0], null.From(1))
+
+	assertEqual(t, nullslices.FromPtrs(ps), []null.T[int]{null.From(100), {}, null.From(0), {}, null.From(3)})
+	assertEqual(t, nullslices.ToPtrs(ints(nil)), []*int(nil))
+	assertEqual(t, nullslices.FromPtrs[int](nil), []null.T[int](nil))
+}
+
+func TestFromValues(t *testing.T) {
+	got := nullslices.FromValues([]string{"a", "", "b"}, func(s string) bool { return s == "" })
+	assertEqual(t, len(got), 3)
+	assertEqual(t, got[0], null.From("a"))
+	assertEqual(t, got[1], null.T[string]{})
+	assertEqual(t, got[2], null.From("b"))
+	assertEqual(t, nullslices.FromValues(nil, func(int) bool { return true }), []null.T[int](nil))
+}
+
+func assertEqual[T any](t *testing.T, x T, y T, opts ...cmp.Option) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y, opts...); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
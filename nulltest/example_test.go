@@ -0,0 +1,25 @@
+package nulltest_test
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nulltest"
+)
+
+func ExampleOption() {
+	type user struct {
+		Name null.T[string]
+		Age  null.T[int]
+	}
+	x := user{Name: null.From("alice")}
+	y := user{Name: null.From("alice")}
+	fmt.Println(cmp.Equal(x, y, nulltest.Option()))
+
+	y.Age = null.From(20)
+	fmt.Println(cmp.Equal(x, y, nulltest.Option()))
+	// Output:
+	// true
+	// false
+}
@@ -0,0 +1,10 @@
+module github.com/qawatake/null/nulltest
+
+go 1.21.1
+
+require (
+	github.com/google/go-cmp v0.5.9
+	github.com/qawatake/null v0.5.0
+)
+
+require gopkg.in/guregu/null.v4 v4.0.0 // indirect
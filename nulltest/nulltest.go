@@ -0,0 +1,83 @@
+// Package nulltest provides test helpers for code using null.T.
+//
+// go-cmp panics on the unexported fields of null.T, and its diffs of them show the internal representation.
+// [Option] compares null.T by [null.T.Equal] and reports the payloads instead:
+//
+//	if diff := cmp.Diff(want, got, nulltest.Option()); diff != "" {
+//		t.Errorf("mismatch (-want +got):\n%s", diff)
+//	}
+//
+// nulltest is a separate module so that the null module does not depend on go-cmp.
+package nulltest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+)
+
+// Option returns a cmp.Option that compares values of null.T[V] by their Equal method.
+// Unequal values are reported as their payloads, or nil if null, so a diff reads
+//
+//	- 	Age: null.T[int](Inverse(null.T, any(int(3)))),
+//	+ 	Age: null.T[int](Inverse(null.T, any(nil))),
+//
+// Other options, such as those for the payload type, apply to the payloads.
+func Option() cmp.Option {
+	return cmp.FilterValues(notEqual, cmp.Transformer("null.T", payload))
+}
+
+var nullPkgPath = reflect.TypeOf(null.T[int]{}).PkgPath()
+
+// isT reports whether t is an instance of null.T.
+func isT(t reflect.Type) bool {
+	return t.PkgPath() == nullPkgPath && strings.HasPrefix(t.Name(), "T[")
+}
+
+func notEqual(x, y any) bool {
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	if !vx.IsValid() || !isT(vx.Type()) || vx.Type() != vy.Type() {
+		return false
+	}
+	return !vx.MethodByName("Equal").Call([]reflect.Value{vy})[0].Bool()
+}
+
+// payload returns the payload of a null.T, or nil if it is null.
+func payload(x any) any {
+	v := reflect.ValueOf(x)
+	if v.MethodByName("IsNull").Call(nil)[0].Bool() {
+		return nil
+	}
+	return v.MethodByName("ValueOrZero").Call(nil)[0].Interface()
+}
+
+// RequireNull fails the test immediately if x is not null.
+func RequireNull[V comparable](tb testing.TB, x null.T[V]) {
+	tb.Helper()
+	if !x.IsNull() {
+		tb.Fatalf("want null, but got %v", x)
+	}
+}
+
+// RequireValue fails the test immediately if x is null or its payload is not equal to want.
+// The payloads are compared by [null.T.Equal].
+func RequireValue[V comparable](tb testing.TB, x null.T[V], want V) {
+	tb.Helper()
+	if !x.Equal(null.From(want)) {
+		tb.Fatalf("want %v, but got %v", null.From(want), x)
+	}
+}
+
+// AssertEqual reports an error with the diff if x and y differ according to cmp.Diff with [Option] and opts.
+// It reports whether they are equal.
+func AssertEqual[T any](tb testing.TB, x, y T, opts ...cmp.Option) bool {
+	tb.Helper()
+	if diff := cmp.Diff(x, y, append([]cmp.Option{Option()}, opts...)...); diff != "" {
+		tb.Errorf("mismatch (-x +y):\n%s", diff)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package nulltest_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nulltest"
+)
+
+type user struct {
+	Name    null.T[string]
+	Age     null.T[int]
+	Created null.T[time.Time]
+	Tags    []null.T[string]
+	Email   null.Sensitive[string]
+}
+
+func TestOption(t *testing.T) {
+	base := user{
+		Name:    null.From("alice"),
+		Created: null.From(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Tags:    []null.T[string]{null.From("a"), {}},
+		Email:   null.Sensitive[string]{T: null.From("alice@example.com")},
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(u *user)
+		opts     []cmp.Option
+		wantDiff []string
+	}{
+		{
+			name:   "equal",
+			modify: func(u *user) {},
+		},
+		{
+			name: "equal by Equal of payload",
+			// The same instant in another location is equal by time.Time.Equal, but not by ==.
+			modify: func(u *user) { u.Created = null.From(u.Created.ValueOrZero().In(time.FixedZone("JST", 9*60*60))) },
+		},
+		{
+			name:     "null and value",
+			modify:   func(u *user) { u.Age = null.From(3) },
+			wantDiff: []string{"null.T[int](Inverse(null.T, any(nil)))", "null.T[int](Inverse(null.T, any(int(3))))"},
+		},
+		{
+			name:     "values",
+			modify:   func(u *user) { u.Name = null.From("bob") },
+			wantDiff: []string{`string("alice")`, `string("bob")`},
+		},
+		{
+			name:     "in slice",
+			modify:   func(u *user) { u.Tags = []null.T[string]{null.From("a"), null.From("b")} },
+			wantDiff: []string{"any(nil)", `any(string("b"))`},
+		},
+		{
+			name:     "embedded in Sensitive",
+			modify:   func(u *user) { u.Email = null.Sensitive[string]{} },
+			wantDiff: []string{`any(string("alice@example.com"))`, "null.Sensitive[string]{}"},
+		},
+		{
+			name:   "payload option",
+			modify: func(u *user) { u.Name = null.From("ALICE") },
+			opts:   []cmp.Option{cmp.Comparer(strings.EqualFold)},
+		},
+		{
+			name:   "other options",
+			modify: func(u *user) { u.Tags = nil },
+			opts:   []cmp.Option{cmpopts.IgnoreFields(user{}, "Tags")},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			u := base
+			u.Tags = append([]null.T[string](nil), base.Tags...)
+			tt.modify(&u)
+			diff := cmp.Diff(base, u, append([]cmp.Option{nulltest.Option()}, tt.opts...)...)
+			if len(tt.wantDiff) == 0 && diff != "" {
+				t.Errorf("want no diff, but got\n%s", diff)
+			}
+			if len(tt.wantDiff) > 0 && diff == "" {
+				t.Error("want diff, but got none")
+			}
+			for _, w := range tt.wantDiff {
+				if !strings.Contains(diff, w) {
+					t.Errorf("diff does not contain %q:\n%s", w, diff)
+				}
+			}
+			if strings.Contains(diff, "Valid") {
+				t.Errorf("diff shows the internal representation:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequireNull(t *testing.T) {
+	assertFailure(t, func(tb testing.TB) { nulltest.RequireNull(tb, null.T[int]{}) }, "")
+	assertFailure(t, func(tb testing.TB) { nulltest.RequireNull(tb, null.From(0)) }, "fatal: want null, but got 0")
+}
+
+func TestRequireValue(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assertFailure(t, func(tb testing.TB) { nulltest.RequireValue(tb, null.From(3), 3) }, "")
+	assertFailure(t, func(tb testing.TB) { nulltest.RequireValue(tb, null.From(at.Local()), at) }, "")
+	assertFailure(t, func(tb testing.TB) { nulltest.RequireValue(tb, null.T[int]{}, 0) }, "fatal: want 0, but got null")
+	assertFailure(t, func(tb testing.TB) { nulltest.RequireValue(tb, null.From("a"), "b") }, "fatal: want b, but got a")
+}
+
+func TestAssertEqual(t *testing.T) {
+	assertFailure(t, func(tb testing.TB) {
+		if !nulltest.AssertEqual(tb, []null.T[int]{null.From(1), {}}, []null.T[int]{null.From(1), {}}) {
+			tb.Error("want true")
+		}
+	}, "")
+	assertFailure(t, func(tb testing.TB) {
+		if nulltest.AssertEqual(tb, null.From(1), null.T[int]{}) {
+			tb.Error("want false")
+		}
+	}, "error: mismatch (-x +y):\n  null.T[int](Inverse(null.T, any(\n- \tint(1),\n+ \tnil,\n  )))\n")
+}
+
+// assertFailure runs f with a recorder and checks the message it reports, which is empty if f does not fail.
+func assertFailure(t *testing.T, f func(tb testing.TB), want string) {
+	t.Helper()
+	r := &recorder{TB: t}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		f(r)
+	}()
+	wg.Wait()
+	// go-cmp randomly replaces some spaces by non-breaking spaces to discourage matching its output.
+	got := strings.ReplaceAll(r.msg, "\u00a0", " ")
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// recorder records the failure of a test instead of failing it.
+type recorder struct {
+	testing.TB
+	msg string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.msg += "error: " + fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Error(args ...any) {
+	r.msg += "error: " + fmt.Sprint(args...)
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.msg += "fatal: " + fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
@@ -0,0 +1,35 @@
+package nulltime_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/qawatake/null/nulltime"
+)
+
+type mysqlDateTime struct{}
+
+func (mysqlDateTime) Format() nulltime.Format {
+	return nulltime.Format{
+		Layouts:   []string{time.DateTime},
+		Location:  time.FixedZone("JST", 9*60*60),
+		Precision: time.Second,
+	}
+}
+
+func ExampleTime() {
+	type Event struct {
+		At nulltime.Time[mysqlDateTime]
+	}
+
+	var ev Event
+	ev.At.Scan("2024-03-10 12:04:05")
+	fmt.Println(ev.At.ValueOrZero())
+
+	data, _ := json.Marshal(ev)
+	fmt.Println(string(data))
+	// Output:
+	// 2024-03-10 21:04:05 +0900 JST
+	// {"At":"2024-03-10 21:04:05"}
+}
@@ -0,0 +1,273 @@
+// Package nulltime provides a nullable time.Time built on top of null.T[time.Time]
+// whose text layouts, JSON encoding, time zone and precision are configurable.
+package nulltime
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"strconv"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+// Encoding selects how a Time is represented in JSON.
+type Encoding int
+
+const (
+	// Text encodes a Time as a JSON string formatted with the first layout.
+	Text Encoding = iota
+	// UnixSeconds encodes a Time as a JSON number of seconds since the Unix epoch.
+	UnixSeconds
+	// UnixMillis encodes a Time as a JSON number of milliseconds since the Unix epoch.
+	UnixMillis
+)
+
+// Format describes how a Time is decoded and encoded.
+// The zero value for Format behaves like null.T[time.Time] except that RFC 3339 text is also accepted by Scan.
+type Format struct {
+	// Layouts are tried in order when parsing text from Scan or UnmarshalJSON.
+	// The first layout is also used by MarshalJSON when JSON is Text.
+	// If Layouts is empty, time.RFC3339Nano is used.
+	Layouts []string
+	// JSON selects the JSON representation.
+	// Numbers given to Scan and UnmarshalJSON are interpreted in the same unit.
+	JSON Encoding
+	// ParseLocation is the location assumed for layouts without zone information.
+	// If ParseLocation is nil, UTC is used.
+	ParseLocation *time.Location
+	// Location, if non-nil, is the location every value is converted to.
+	Location *time.Location
+	// Precision, if positive, is the precision every value is truncated to.
+	Precision time.Duration
+}
+
+// Formatter provides a Format for Time.
+// It is intended to be implemented by an empty struct type:
+//
+//	type mysqlDateTime struct{}
+//
+//	func (mysqlDateTime) Format() nulltime.Format {
+//		return nulltime.Format{Layouts: []string{time.DateTime}}
+//	}
+type Formatter interface {
+	Format() Format
+}
+
+// Time represents a time.Time that may be null.
+// The zero value for Time is ready for use.
+//
+// Values are normalized to F's location and precision on construction, Scan and UnmarshalJSON.
+// Time is comparable, but like null.T[time.Time] it should be compared using [Time.Equal].
+type Time[F Formatter] struct {
+	t null.T[time.Time]
+}
+
+// From creates a new Time that is valid.
+func From[F Formatter](t time.Time) Time[F] {
+	var f F
+	return Time[F]{t: null.From(normalize(f.Format(), t))}
+}
+
+// FromPtr creates a new Time that is null if p is nil.
+func FromPtr[F Formatter](p *time.Time) Time[F] {
+	if p == nil {
+		return Time[F]{}
+	}
+	return From[F](*p)
+}
+
+// FromNull creates a new Time from t.
+func FromNull[F Formatter](t null.T[time.Time]) Time[F] {
+	if t.IsNull() {
+		return Time[F]{}
+	}
+	return From[F](t.ValueOrZero())
+}
+
+// Null returns t as null.T[time.Time].
+func (t Time[F]) Null() null.T[time.Time] {
+	return t.t
+}
+
+var _ sql.Scanner = &Time[Format]{}
+
+// Scan implements the sql.Scanner interface.
+// In addition to time.Time, it accepts text in one of F's layouts and,
+// if F's JSON encoding is UnixSeconds or UnixMillis, integers in that unit.
+func (t *Time[F]) Scan(src any) error {
+	var f F
+	format := f.Format()
+	var (
+		v   time.Time
+		err error
+	)
+	switch s := src.(type) {
+	case nil:
+		*t = Time[F]{}
+		return nil
+	case time.Time:
+		v = s
+	case string:
+		v, err = format.parse(s)
+	case []byte:
+		v, err = format.parse(string(s))
+	case int64:
+		v, err = format.unix(s)
+	default:
+		err = fmt.Errorf("nulltime: unsupported Scan, storing driver.Value type %T into type %T", src, t)
+	}
+	if err != nil {
+		*t = Time[F]{}
+		return err
+	}
+	*t = Time[F]{t: null.From(normalize(format, v))}
+	return nil
+}
+
+var _ driver.Valuer = Time[Format]{}
+
+// Value implements the driver.Valuer interface.
+func (t Time[F]) Value() (driver.Value, error) {
+	return t.t.Value()
+}
+
+var _ json.Unmarshaler = &Time[Format]{}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts a string in one of F's layouts and,
+// if F's JSON encoding is UnixSeconds or UnixMillis, an integer in that unit.
+func (t *Time[F]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullBytes) {
+		*t = Time[F]{}
+		return nil
+	}
+	var f F
+	format := f.Format()
+	var (
+		v   time.Time
+		err error
+	)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err = json.Unmarshal(data, &s); err == nil {
+			v, err = format.parse(s)
+		}
+	} else {
+		var n int64
+		if err = json.Unmarshal(data, &n); err == nil {
+			v, err = format.unix(n)
+		}
+	}
+	if err != nil {
+		*t = Time[F]{}
+		return err
+	}
+	*t = Time[F]{t: null.From(normalize(format, v))}
+	return nil
+}
+
+var _ json.Marshaler = Time[Format]{}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Time[F]) MarshalJSON() ([]byte, error) {
+	if t.IsNull() {
+		return []byte("null"), nil
+	}
+	var f F
+	format := f.Format()
+	v := t.t.ValueOrZero()
+	switch format.JSON {
+	case UnixSeconds:
+		return strconv.AppendInt(nil, v.Unix(), 10), nil
+	case UnixMillis:
+		return strconv.AppendInt(nil, v.UnixMilli(), 10), nil
+	}
+	return json.Marshal(v.Format(format.layouts()[0]))
+}
+
+// Equal reports whether t and u are equal in the sense of [null.T.Equal].
+func (t Time[F]) Equal(u Time[F]) bool {
+	return t.t.Equal(u.t)
+}
+
+// Hash writes t to h consistently with Equal, as [null.T.Hash] does.
+func (t Time[F]) Hash(h *maphash.Hash) {
+	t.t.Hash(h)
+}
+
+// ValueOrZero returns the inner time.Time.
+// If t is null (that is, t.IsNull() returns true), it returns the zero value of time.Time.
+func (t Time[F]) ValueOrZero() time.Time {
+	return t.t.ValueOrZero()
+}
+
+// Ptr returns a pointer to the internal value, but it provides a different reference with each call.
+// If t is null (that is, t.IsNull() returns true), it returns nil.
+func (t Time[F]) Ptr() *time.Time {
+	return t.t.Ptr()
+}
+
+// IsNull reports whether t is null.
+func (t Time[F]) IsNull() bool {
+	return t.t.IsNull()
+}
+
+// Format implements Formatter, so that Format can be used as its own type argument
+// when the zero Format is sufficient.
+func (f Format) Format() Format {
+	return f
+}
+
+func (f Format) layouts() []string {
+	if len(f.Layouts) == 0 {
+		return defaultLayouts
+	}
+	return f.Layouts
+}
+
+func (f Format) parse(s string) (time.Time, error) {
+	loc := f.ParseLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	var errs []error
+	for _, layout := range f.layouts() {
+		v, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return v, nil
+		}
+		errs = append(errs, err)
+	}
+	return time.Time{}, fmt.Errorf("nulltime: cannot parse %q: %w", s, errors.Join(errs...))
+}
+
+func (f Format) unix(n int64) (time.Time, error) {
+	switch f.JSON {
+	case UnixSeconds:
+		return time.Unix(n, 0).UTC(), nil
+	case UnixMillis:
+		return time.UnixMilli(n).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("nulltime: cannot convert integer %d to time.Time without a Unix encoding", n)
+}
+
+func normalize(f Format, t time.Time) time.Time {
+	if f.Location != nil {
+		t = t.In(f.Location)
+	}
+	if f.Precision > 0 {
+		t = t.Truncate(f.Precision)
+	}
+	return t
+}
+
+var defaultLayouts = []string{time.RFC3339Nano}
+
+// nullBytes is a JSON null literal
+var nullBytes = []byte("null")
@@ -0,0 +1,243 @@
+package nulltime_test
+
+import (
+	"encoding/json"
+	"hash/maphash"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/nulltime"
+)
+
+var tokyo = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+type partner struct{}
+
+func (partner) Format() nulltime.Format {
+	return nulltime.Format{
+		Layouts:   []string{time.DateTime, time.RFC3339Nano},
+		Location:  tokyo,
+		Precision: time.Second,
+	}
+}
+
+type epochSeconds struct{}
+
+func (epochSeconds) Format() nulltime.Format {
+	return nulltime.Format{
+		JSON:     nulltime.UnixSeconds,
+		Location: time.UTC,
+	}
+}
+
+type epochMillis struct{}
+
+func (epochMillis) Format() nulltime.Format {
+	return nulltime.Format{
+		JSON:     nulltime.UnixMillis,
+		Location: time.UTC,
+	}
+}
+
+func TestScan(t *testing.T) {
+	want := time.Date(2024, 3, 10, 21, 4, 5, 0, tokyo)
+	tests := []struct {
+		name       string
+		src        any
+		wantValue  time.Time
+		wantIsNull bool
+		wantErr    bool
+	}{
+		{
+			name:       "nil",
+			src:        nil,
+			wantIsNull: true,
+		},
+		{
+			name:      "time.Time is normalized",
+			src:       time.Date(2024, 3, 10, 12, 4, 5, 999, time.UTC),
+			wantValue: want,
+		},
+		{
+			name:      "string in the first layout",
+			src:       "2024-03-10 12:04:05",
+			wantValue: want,
+		},
+		{
+			name:      "[]byte in the second layout",
+			src:       []byte("2024-03-10T12:04:05.5Z"),
+			wantValue: want,
+		},
+		{
+			name:       "string in no layout",
+			src:        "10/03/2024",
+			wantIsNull: true,
+			wantErr:    true,
+		},
+		{
+			name:       "int64 without a Unix encoding",
+			src:        int64(1710072245),
+			wantIsNull: true,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported type",
+			src:        1.5,
+			wantIsNull: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v := nulltime.From[partner](time.Now())
+			err := v.Scan(tt.src)
+			assertEqual(t, err != nil, tt.wantErr)
+			assertEqual(t, v.IsNull(), tt.wantIsNull)
+			assertEqual(t, v.ValueOrZero(), tt.wantValue)
+		})
+	}
+
+	t.Run("int64 with UnixSeconds", func(t *testing.T) {
+		var v nulltime.Time[epochSeconds]
+		requireNoError(t, v.Scan(int64(1710072245)))
+		assertEqual(t, v.ValueOrZero(), time.Date(2024, 3, 10, 12, 4, 5, 0, time.UTC))
+	})
+}
+
+func TestValue(t *testing.T) {
+	v, err := nulltime.From[partner](time.Date(2024, 3, 10, 12, 4, 5, 0, time.UTC)).Value()
+	requireNoError(t, err)
+	assertEqual(t, v, any(time.Date(2024, 3, 10, 21, 4, 5, 0, tokyo)))
+
+	v, err = nulltime.Time[partner]{}.Value()
+	requireNoError(t, err)
+	assertEqual(t, v, nil)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Run("layouts", func(t *testing.T) {
+		var v nulltime.Time[partner]
+		requireNoError(t, json.Unmarshal([]byte(`"2024-03-10 12:04:05"`), &v))
+		assertEqual(t, v.ValueOrZero(), time.Date(2024, 3, 10, 21, 4, 5, 0, tokyo))
+
+		requireError(t, json.Unmarshal([]byte(`1710072245`), &v))
+		assertEqual(t, v.IsNull(), true)
+
+		requireNoError(t, json.Unmarshal([]byte(`"2024-03-10T12:04:05Z"`), &v))
+		requireNoError(t, json.Unmarshal([]byte(`null`), &v))
+		assertEqual(t, v.IsNull(), true)
+	})
+
+	t.Run("UnixSeconds", func(t *testing.T) {
+		var v nulltime.Time[epochSeconds]
+		requireNoError(t, json.Unmarshal([]byte(`1710072245`), &v))
+		assertEqual(t, v.ValueOrZero(), time.Date(2024, 3, 10, 12, 4, 5, 0, time.UTC))
+
+		requireError(t, json.Unmarshal([]byte(`1710072245.5`), &v))
+		assertEqual(t, v.IsNull(), true)
+	})
+
+	t.Run("UnixMillis", func(t *testing.T) {
+		var v nulltime.Time[epochMillis]
+		requireNoError(t, json.Unmarshal([]byte(`1710072245123`), &v))
+		assertEqual(t, v.ValueOrZero(), time.Date(2024, 3, 10, 12, 4, 5, 123000000, time.UTC))
+	})
+}
+
+func TestMarshalJSON(t *testing.T) {
+	at := time.Date(2024, 3, 10, 12, 4, 5, 123000000, time.UTC)
+	tests := []struct {
+		name     string
+		marshal  func() ([]byte, error)
+		wantData string
+	}{
+		{
+			name:     "layout",
+			marshal:  nulltime.From[partner](at).MarshalJSON,
+			wantData: `"2024-03-10 21:04:05"`,
+		},
+		{
+			name:     "default layout",
+			marshal:  nulltime.From[nulltime.Format](at).MarshalJSON,
+			wantData: `"2024-03-10T12:04:05.123Z"`,
+		},
+		{
+			name:     "UnixSeconds",
+			marshal:  nulltime.From[epochSeconds](at).MarshalJSON,
+			wantData: `1710072245`,
+		},
+		{
+			name:     "UnixMillis",
+			marshal:  nulltime.From[epochMillis](at).MarshalJSON,
+			wantData: `1710072245123`,
+		},
+		{
+			name:     "null",
+			marshal:  nulltime.Time[epochMillis]{}.MarshalJSON,
+			wantData: `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.marshal()
+			requireNoError(t, err)
+			assertEqual(t, string(data), tt.wantData)
+		})
+	}
+}
+
+func TestFromNull(t *testing.T) {
+	at := time.Date(2024, 3, 10, 12, 4, 5, 0, time.UTC)
+	v := nulltime.FromNull[partner](null.From(at))
+	assertEqual(t, v.Null().Equal(null.From(at)), true)
+	assertEqual(t, v == nulltime.From[partner](at), true)
+	assertEqual(t, nulltime.FromNull[partner](null.T[time.Time]{}).IsNull(), true)
+	assertEqual(t, nulltime.FromPtr[partner](nil).IsNull(), true)
+	assertEqual(t, nulltime.FromPtr[partner](&at).Equal(v), true)
+}
+
+func TestHash(t *testing.T) {
+	sum := func(v nulltime.Time[epochSeconds]) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		v.Hash(&h)
+		return h.Sum64()
+	}
+	at := time.Date(2024, 3, 10, 12, 4, 5, 0, time.UTC)
+	x := nulltime.FromNull[epochSeconds](null.From(at))
+	y := nulltime.FromNull[epochSeconds](null.From(at.In(tokyo)))
+	assertEqual(t, x.Equal(y), true)
+	assertEqual(t, sum(x), sum(y))
+	assertEqual(t, sum(x) != sum(nulltime.Time[epochSeconds]{}), true)
+}
+
+var seed = maphash.MakeSeed()
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
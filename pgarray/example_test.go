@@ -0,0 +1,39 @@
+package pgarray_test
+
+import (
+	"fmt"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/pgarray"
+)
+
+func ExampleArray() {
+	var a pgarray.Array[int]
+	if err := a.Scan(`{1,NULL,3}`); err != nil {
+		panic(err)
+	}
+	for _, e := range a {
+		fmt.Println(e.Ptr() != nil, e.ValueOrZero())
+	}
+
+	v, _ := pgarray.Array[string]{null.From("a,b"), {}, null.From("NULL")}.Value()
+	fmt.Println(v)
+	// Output:
+	// true 1
+	// false 0
+	// true 3
+	// {"a,b",NULL,"NULL"}
+}
+
+func ExampleRow() {
+	var (
+		street null.T[string]
+		zip    null.T[string]
+	)
+	if err := (pgarray.Row{&street, &zip}).Scan(`("1 Main St",)`); err != nil {
+		panic(err)
+	}
+	fmt.Println(street.ValueOrZero(), zip.IsNull())
+	// Output:
+	// 1 Main St true
+}
@@ -0,0 +1,45 @@
+package pgarray_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/pgarray"
+)
+
+func FuzzArray(f *testing.F) {
+	for _, s := range []string{"", "NULL", `a "b"`, `c\d`, "{x,y}", " ", "(1,2)"} {
+		f.Add(s, s)
+	}
+	f.Fuzz(func(t *testing.T, a, b string) {
+		in := pgarray.Array[string]{null.From(a), {}, null.From(b)}
+		v, err := in.Value()
+		if err != nil {
+			t.Fatalf("a: %q, b: %q, err: %v", a, b, err)
+		}
+		var out pgarray.Array[string]
+		if err := out.Scan(v); err != nil {
+			t.Fatalf("text: %q, err: %v", v, err)
+		}
+		assertEqual(t, out, in)
+	})
+}
+
+func FuzzRow(f *testing.F) {
+	for _, s := range []string{"", "NULL", `a "b"`, `c\d`, "(x,y)", " "} {
+		f.Add(s, s)
+	}
+	f.Fuzz(func(t *testing.T, a, b string) {
+		v, err := pgarray.Row{null.From(a), null.T[string]{}, null.From(b)}.Value()
+		if err != nil {
+			t.Fatalf("a: %q, b: %q, err: %v", a, b, err)
+		}
+		var x, y, z null.T[string]
+		if err := (pgarray.Row{&x, &y, &z}).Scan(v); err != nil {
+			t.Fatalf("text: %q, err: %v", v, err)
+		}
+		assertEqual(t, x, null.From(a))
+		assertEqual(t, y.IsNull(), true)
+		assertEqual(t, z, null.From(b))
+	})
+}
@@ -0,0 +1,403 @@
+// Package pgarray scans and values PostgreSQL arrays and composite values with nullable elements
+// over plain database/sql, using the text format.
+//
+// Elements are decoded by the Scan method of null.T from their text,
+// so an element of type V is scanned as a string column of V would be,
+// except that the text of a time.Time, which database/sql does not convert from text, is parsed as a timestamp.
+// Elements are encoded from the result of their Value method,
+// and floating-point NaN and infinities as NaN, Infinity and -Infinity.
+package pgarray
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/internal/pgtime"
+)
+
+// Array is a one-dimensional PostgreSQL array whose elements may be NULL, such as {1,NULL,3}.
+// A nil Array is NULL.
+type Array[V comparable] []null.T[V]
+
+var (
+	_ sql.Scanner   = (*Array[int])(nil)
+	_ driver.Valuer = Array[int]{}
+)
+
+// Scan implements the sql.Scanner interface.
+func (a *Array[V]) Scan(src any) error {
+	s, ok, err := text(src)
+	if err != nil || !ok {
+		*a = nil
+		return err
+	}
+	elems, err := parseArray(s)
+	if err != nil {
+		return err
+	}
+	arr := make(Array[V], len(elems))
+	for i, e := range elems {
+		if err := scanText(&arr[i], e); err != nil {
+			return fmt.Errorf("pgarray: element %d: %w", i+1, err)
+		}
+	}
+	*a = arr
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (a Array[V]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		v, err := e.Value()
+		if err != nil {
+			return nil, fmt.Errorf("pgarray: element %d: %w", i+1, err)
+		}
+		if v == nil {
+			b.WriteString("NULL")
+			continue
+		}
+		s, err := format(v)
+		if err != nil {
+			return nil, fmt.Errorf("pgarray: element %d: %w", i+1, err)
+		}
+		writeQuoted(&b, s, arrayNeedsQuote(s), false)
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Row is a PostgreSQL composite value, such as (1,,"a b"), whose fields may be NULL.
+//
+// To scan, each element of Row must implement sql.Scanner, such as *null.T[V];
+// a NULL composite value scans NULL into every field.
+// To value, each element is a driver.Valuer, such as null.T[V], or a value accepted by database/sql.
+type Row []any
+
+var (
+	_ sql.Scanner   = Row{}
+	_ driver.Valuer = Row{}
+)
+
+// Scan implements the sql.Scanner interface.
+func (r Row) Scan(src any) error {
+	s, ok, err := text(src)
+	if err != nil {
+		return err
+	}
+	var fields []null.T[string]
+	if ok {
+		if fields, err = parseRow(s); err != nil {
+			return err
+		}
+		if len(fields) != len(r) {
+			return fmt.Errorf("pgarray: composite value has %d fields, but Row has %d", len(fields), len(r))
+		}
+	}
+	for i, dest := range r {
+		scanner, ok := dest.(sql.Scanner)
+		if !ok {
+			return fmt.Errorf("pgarray: field %d: %T does not implement sql.Scanner", i+1, dest)
+		}
+		var f null.T[string]
+		if fields != nil {
+			f = fields[i]
+		}
+		if err := scanText(scanner, f); err != nil {
+			return fmt.Errorf("pgarray: field %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (r Row) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, f := range r {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		v, err := driver.DefaultParameterConverter.ConvertValue(f)
+		if err != nil {
+			return nil, fmt.Errorf("pgarray: field %d: %w", i+1, err)
+		}
+		if v == nil {
+			// NULL is written as nothing.
+			continue
+		}
+		s, err := format(v)
+		if err != nil {
+			return nil, fmt.Errorf("pgarray: field %d: %w", i+1, err)
+		}
+		writeQuoted(&b, s, rowNeedsQuote(s), true)
+	}
+	b.WriteByte(')')
+	return b.String(), nil
+}
+
+// text returns src as a string. ok is false if src is nil.
+func text(src any) (s string, ok bool, err error) {
+	switch src := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return src, true, nil
+	case []byte:
+		return string(src), true, nil
+	default:
+		return "", false, fmt.Errorf("pgarray: cannot scan %T", src)
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// scanText scans the text of an element, or NULL, into dest.
+// The text is parsed as a timestamp first if dest is a null.T or null.Sensitive of time.Time.
+func scanText(dest sql.Scanner, s null.T[string]) error {
+	text, ok := s.Get()
+	if !ok {
+		return dest.Scan(nil)
+	}
+	if n, ok := dest.(null.Nullable); ok && n.PayloadType() == timeType {
+		t, err := pgtime.Parse(text)
+		if err != nil {
+			return err
+		}
+		return dest.Scan(t)
+	}
+	return dest.Scan(text)
+}
+
+// format returns the text representation of a driver.Value as PostgreSQL reads it.
+func format(v driver.Value) (string, error) {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN", nil
+		case math.IsInf(v, 1):
+			return "Infinity", nil
+		case math.IsInf(v, -1):
+			return "-Infinity", nil
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		if v {
+			return "t", nil
+		}
+		return "f", nil
+	case string:
+		return v, nil
+	case []byte:
+		return `\x` + hex.EncodeToString(v), nil
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999999Z07:00"), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+func arrayNeedsQuote(s string) bool {
+	return s == "" || strings.EqualFold(s, "NULL") || strings.ContainsAny(s, "{},\"\\ \t\n\r\v\f")
+}
+
+func rowNeedsQuote(s string) bool {
+	return s == "" || strings.ContainsAny(s, "(),\"\\ \t\n\r\v\f")
+}
+
+// writeQuoted writes s, quoted if quote is true.
+// Within quotes, a double quote is doubled in composite values and escaped by a backslash in arrays.
+func writeQuoted(b *strings.Builder, s string, quote, composite bool) {
+	if !quote {
+		b.WriteString(s)
+		return
+	}
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			if composite {
+				b.WriteString(`""`)
+			} else {
+				b.WriteString(`\"`)
+			}
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+}
+
+var errMultiDim = errors.New("pgarray: multidimensional arrays are not supported")
+
+// parseArray parses the text of a one-dimensional array.
+func parseArray(s string) ([]null.T[string], error) {
+	// Skip the optional dimension decoration, such as [0:2]=.
+	if strings.HasPrefix(s, "[") {
+		i := strings.IndexByte(s, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("pgarray: malformed array %q", s)
+		}
+		if strings.Count(s[:i], "[") > 1 {
+			return nil, errMultiDim
+		}
+		s = s[i+1:]
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("pgarray: malformed array %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if strings.TrimSpace(body) == "" {
+		return []null.T[string]{}, nil
+	}
+	var elems []null.T[string]
+	p := parser{s: body}
+	for {
+		p.skipSpace()
+		if p.peek() == '{' {
+			return nil, errMultiDim
+		}
+		e, quoted, err := p.element()
+		if err != nil {
+			return nil, fmt.Errorf("pgarray: malformed array %q: %w", s, err)
+		}
+		switch {
+		case !quoted && e == "":
+			return nil, fmt.Errorf("pgarray: malformed array %q: empty element", s)
+		case !quoted && strings.EqualFold(e, "NULL"):
+			elems = append(elems, null.T[string]{})
+		default:
+			elems = append(elems, null.From(e))
+		}
+		if p.eof() {
+			return elems, nil
+		}
+		p.i++ // ','
+	}
+}
+
+// parseRow parses the text of a composite value.
+func parseRow(s string) ([]null.T[string], error) {
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("pgarray: malformed composite value %q", s)
+	}
+	var fields []null.T[string]
+	p := parser{s: s[1 : len(s)-1], composite: true}
+	for {
+		f, quoted, err := p.element()
+		if err != nil {
+			return nil, fmt.Errorf("pgarray: malformed composite value %q: %w", s, err)
+		}
+		if !quoted && f == "" {
+			fields = append(fields, null.T[string]{})
+		} else {
+			fields = append(fields, null.From(f))
+		}
+		if p.eof() {
+			return fields, nil
+		}
+		p.i++ // ','
+	}
+}
+
+type parser struct {
+	s         string
+	i         int
+	composite bool
+}
+
+func (p *parser) eof() bool { return p.i >= len(p.s) }
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && isSpace(p.s[p.i]) {
+		p.i++
+	}
+}
+
+// element reads an element up to a comma or the end.
+// quoted reports whether any part of the element was quoted or escaped.
+// Unquoted whitespace around array elements is ignored; it is kept in composite values.
+func (p *parser) element() (s string, quoted bool, err error) {
+	var b strings.Builder
+	inQuote := false
+	// trailing is the length of b without the trailing unquoted whitespace.
+	trailing := 0
+	if !p.composite {
+		p.skipSpace()
+	}
+	for ; !p.eof(); p.i++ {
+		c := p.s[p.i]
+		switch {
+		case c == '\\':
+			p.i++
+			if p.eof() {
+				return "", false, errors.New("unexpected end after backslash")
+			}
+			b.WriteByte(p.s[p.i])
+			quoted = true
+			trailing = b.Len()
+		case c == '"':
+			if inQuote && p.composite && p.i+1 < len(p.s) && p.s[p.i+1] == '"' {
+				p.i++
+				b.WriteByte('"')
+				trailing = b.Len()
+				continue
+			}
+			inQuote = !inQuote
+			quoted = true
+			trailing = b.Len()
+		case inQuote:
+			b.WriteByte(c)
+			trailing = b.Len()
+		case c == ',':
+			return b.String()[:trailing], quoted, nil
+		case !p.composite && (c == '{' || c == '}'):
+			return "", false, fmt.Errorf("unexpected %q", c)
+		default:
+			b.WriteByte(c)
+			if p.composite || !isSpace(c) {
+				trailing = b.Len()
+			}
+		}
+	}
+	if inQuote {
+		return "", false, errors.New("unterminated quote")
+	}
+	return b.String()[:trailing], quoted, nil
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
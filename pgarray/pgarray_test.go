@@ -0,0 +1,247 @@
+package pgarray_test
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/civil"
+	"github.com/qawatake/null/internal/fakedb"
+	"github.com/qawatake/null/pgarray"
+)
+
+func TestArray_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    pgarray.Array[int64]
+		wantErr bool
+	}{
+		{name: "nil", src: nil, want: nil},
+		{name: "empty", src: "{}", want: pgarray.Array[int64]{}},
+		{name: "NULL element", src: "{1,NULL,3}", want: pgarray.Array[int64]{null.From[int64](1), {}, null.From[int64](3)}},
+		{name: "spaces and quotes", src: []byte(`{ 1 , null ,"3"}`), want: pgarray.Array[int64]{null.From[int64](1), {}, null.From[int64](3)}},
+		{name: "dimension decoration", src: "[0:1]={-1,2}", want: pgarray.Array[int64]{null.From[int64](-1), null.From[int64](2)}},
+		{name: "invalid element", src: "{1,x}", wantErr: true},
+		{name: "multidimensional", src: "{{1,2},{3,4}}", wantErr: true},
+		{name: "multidimensional decoration", src: "[1:2][1:1]={{1},{2}}", wantErr: true},
+		{name: "empty element", src: "{1,}", wantErr: true},
+		{name: "unterminated", src: "{1", wantErr: true},
+		{name: "unterminated quote", src: `{"1}`, wantErr: true},
+		{name: "unsupported type", src: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got pgarray.Array[int64]
+			err := got.Scan(tt.src)
+			assertEqual(t, err != nil, tt.wantErr)
+			if err == nil {
+				assertEqual(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArray_ScanString(t *testing.T) {
+	var got pgarray.Array[string]
+	requireNoError(t, got.Scan(`{plain,"with space","quote\"d","back\\slash","NULL",NULL,"","{}",esc\,aped}`))
+	assertEqual(t, got, pgarray.Array[string]{
+		null.From("plain"),
+		null.From("with space"),
+		null.From(`quote"d`),
+		null.From(`back\slash`),
+		null.From("NULL"),
+		{},
+		null.From(""),
+		null.From("{}"),
+		null.From("esc,aped"),
+	})
+}
+
+func TestArray_ScanPayloads(t *testing.T) {
+	var b pgarray.Array[bool]
+	requireNoError(t, b.Scan("{t,f,NULL}"))
+	assertEqual(t, b, pgarray.Array[bool]{null.From(true), null.From(false), {}})
+
+	var d pgarray.Array[civil.Date]
+	requireNoError(t, d.Scan(`{2024-02-29,NULL}`))
+	assertEqual(t, d, pgarray.Array[civil.Date]{null.From(civil.Date{Year: 2024, Month: time.February, Day: 29}), {}})
+
+	var f pgarray.Array[float64]
+	requireNoError(t, f.Scan("{NaN,Infinity,-Infinity,1.5}"))
+	assertEqual(t, len(f), 4)
+	assertEqual(t, math.IsNaN(f[0].ValueOrZero()), true)
+	assertEqual(t, f[1:], pgarray.Array[float64]{null.From(math.Inf(1)), null.From(math.Inf(-1)), null.From(1.5)})
+}
+
+func TestArray_ScanTime(t *testing.T) {
+	jst := time.FixedZone("", 9*60*60)
+	tests := []struct {
+		name    string
+		src     string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "timestamptz", src: `{"2024-03-10 12:04:05+09"}`, want: time.Date(2024, 3, 10, 12, 4, 5, 0, jst)},
+		{name: "timestamptz with fraction", src: `{"2024-03-10 12:04:05.123456+09"}`, want: time.Date(2024, 3, 10, 12, 4, 5, 123456000, jst)},
+		{name: "offset with minutes", src: `{"2024-03-10 12:04:05+05:30"}`, want: time.Date(2024, 3, 10, 12, 4, 5, 0, time.FixedZone("", 5*60*60+30*60))},
+		{name: "timestamp", src: `{"2024-03-10 12:04:05"}`, want: time.Date(2024, 3, 10, 12, 4, 5, 0, time.UTC)},
+		{name: "date", src: `{2024-03-10}`, want: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid", src: `{"2024-03-10 25:00:00"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got pgarray.Array[time.Time]
+			err := got.Scan(tt.src)
+			assertEqual(t, err != nil, tt.wantErr)
+			if err == nil {
+				assertEqual(t, len(got), 1)
+				assertEqual(t, got[0].ValueOrZero().Equal(tt.want), true)
+				_, offset := got[0].ValueOrZero().Zone()
+				_, wantOffset := tt.want.Zone()
+				assertEqual(t, offset, wantOffset)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		in := pgarray.Array[time.Time]{null.From(time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)), {}}
+		v, err := in.Value()
+		requireNoError(t, err)
+		var out pgarray.Array[time.Time]
+		requireNoError(t, out.Scan(v))
+		assertEqual(t, out, in)
+	})
+}
+
+func TestArray_Value(t *testing.T) {
+	tests := []struct {
+		name string
+		in   driver.Valuer
+		want driver.Value
+	}{
+		{name: "empty", in: pgarray.Array[int]{}, want: "{}"},
+		{name: "NULL", in: pgarray.Array[int](nil), want: nil},
+		{name: "int", in: pgarray.Array[int]{null.From(1), {}, null.From(-3)}, want: "{1,NULL,-3}"},
+		{name: "float", in: pgarray.Array[float64]{null.From(1.5), null.From(1e100)}, want: "{1.5,1e+100}"},
+		{name: "float NaN and infinities", in: pgarray.Array[float64]{null.From(math.NaN()), null.From(math.Inf(1)), null.From(math.Inf(-1))}, want: "{NaN,Infinity,-Infinity}"},
+		{name: "bool", in: pgarray.Array[bool]{null.From(true), null.From(false)}, want: "{t,f}"},
+		{
+			name: "string",
+			in:   pgarray.Array[string]{null.From("a"), null.From(""), null.From("null"), null.From(`a "b"`), null.From(`c\d`), null.From("{x,y}"), {}},
+			want: `{a,"","null","a \"b\"","c\\d","{x,y}",NULL}`,
+		},
+		{
+			name: "time",
+			in:   pgarray.Array[time.Time]{null.From(time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC))},
+			want: `{"2024-01-02 03:04:05.0000006Z"}`,
+		},
+		{name: "civil.Date", in: pgarray.Array[civil.Date]{null.From(civil.Date{Year: 2024, Month: time.March, Day: 1})}, want: "{2024-03-01}"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.Value()
+			requireNoError(t, err)
+			assertEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestArray_RoundTrip(t *testing.T) {
+	in := pgarray.Array[string]{null.From(" a "), null.From("NULL"), {}, null.From(`"\`), null.From("{,}"), null.From("")}
+	v, err := in.Value()
+	requireNoError(t, err)
+	var out pgarray.Array[string]
+	requireNoError(t, out.Scan(v))
+	assertEqual(t, out, in)
+}
+
+func TestRow(t *testing.T) {
+	var (
+		id    null.T[int64]
+		name  null.T[string]
+		note  null.T[string]
+		memo  null.T[string]
+		score null.T[float64]
+	)
+	requireNoError(t, pgarray.Row{&id, &name, &note, &memo}.Scan(`(42,"Smith, ""J""",,"")`))
+	assertEqual(t, id, null.From[int64](42))
+	assertEqual(t, name, null.From(`Smith, "J"`))
+	assertEqual(t, note.IsNull(), true)
+	assertEqual(t, memo, null.From(""))
+
+	// An empty quoted field is not NULL.
+	requireError(t, pgarray.Row{&id, &score}.Scan(`(1,"")`))
+
+	// Whitespace is significant in composite values.
+	requireNoError(t, pgarray.Row{&id, &name}.Scan(`(1,a b )`))
+	assertEqual(t, name, null.From("a b "))
+
+	requireNoError(t, pgarray.Row{&id, &name}.Scan(nil))
+	assertEqual(t, id.IsNull(), true)
+	assertEqual(t, name.IsNull(), true)
+
+	requireError(t, pgarray.Row{&id}.Scan("(1,2)"))
+	requireError(t, pgarray.Row{id}.Scan("(1)"))
+	requireError(t, pgarray.Row{&id}.Scan("1"))
+	requireError(t, pgarray.Row{&id}.Scan("(x)"))
+
+	var at null.T[time.Time]
+	requireNoError(t, pgarray.Row{&id, &at}.Scan(`(1,"2024-03-10 12:04:05+00")`))
+	assertEqual(t, at, null.From(time.Date(2024, 3, 10, 12, 4, 5, 0, time.UTC)))
+	requireNoError(t, pgarray.Row{&id, &at}.Scan(`(1,)`))
+	assertEqual(t, at.IsNull(), true)
+
+	v, err := pgarray.Row{null.From(1), null.T[string]{}, null.From(""), null.From(`a "b"`), "plain", true}.Value()
+	requireNoError(t, err)
+	assertEqual(t, v, driver.Value(`(1,,"","a ""b""",plain,t)`))
+}
+
+func TestDatabaseSQL(t *testing.T) {
+	fake := &fakedb.DB{Columns: []string{"a", "r"}, Rows: [][]driver.Value{{[]byte("{1,NULL,3}"), "(7,x)"}}}
+	db := fake.Open()
+
+	in := pgarray.Array[int]{null.From(1), {}}
+	var (
+		got  pgarray.Array[int]
+		id   null.T[int]
+		name null.T[string]
+	)
+	requireNoError(t, db.QueryRow("SELECT", in).Scan(&got, pgarray.Row{&id, &name}))
+	assertEqual(t, got, pgarray.Array[int]{null.From(1), {}, null.From(3)})
+	assertEqual(t, id, null.From(7))
+	assertEqual(t, name, null.From("x"))
+	assertEqual(t, fake.Args()[0].Value, driver.Value("{1,NULL}"))
+}
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
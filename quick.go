@@ -0,0 +1,110 @@
+package null
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// MEMO: This file does not import testing/quick, which registers the -quickchecks flag
+// in every program importing it. T implements quick.Generator structurally.
+
+// quickNullChance is the inverse of the probability that Generate returns null.
+const quickNullChance = 4
+
+// generator is the quick.Generator interface.
+type generator interface {
+	Generate(rand *rand.Rand, size int) reflect.Value
+}
+
+var _ generator = T[int]{}
+
+// Generate implements the quick.Generator interface of testing/quick,
+// so that functions taking T are checked by quick.Check.
+// It returns null with probability 1/4 and otherwise a random payload, generated as quick.Value does.
+// Payloads of type time.Time are random instants in UTC.
+// It panics if quick.Value cannot generate V, for example if V is a pointer, channel or interface type.
+func (t T[V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	if rand.Intn(quickNullChance) == 0 {
+		return reflect.ValueOf(T[V]{})
+	}
+	typ := reflect.TypeOf((*V)(nil)).Elem()
+	v, ok := randomValue(typ, rand, size)
+	if !ok {
+		panic(fmt.Sprintf("null: cannot generate a random value of type %v", typ))
+	}
+	return reflect.ValueOf(From(v.Interface().(V)))
+}
+
+var (
+	generatorType = reflect.TypeOf((*generator)(nil)).Elem()
+	timeType      = reflect.TypeOf(time.Time{})
+)
+
+// randomValue returns a random value of a comparable type t like quick.Value.
+func randomValue(t reflect.Type, rand *rand.Rand, size int) (reflect.Value, bool) {
+	if t.Implements(generatorType) {
+		return reflect.Zero(t).Interface().(generator).Generate(rand, size), true
+	}
+	if t == timeType {
+		// Between 1970 and 2100 with nanoseconds.
+		return reflect.ValueOf(time.Unix(rand.Int63n(130*365*24*60*60), rand.Int63n(1e9)).UTC()), true
+	}
+
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(rand.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(rand.Uint64()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(rand.Uint64())
+	case reflect.Float32:
+		v.SetFloat(randomFloat(rand, math.MaxFloat32))
+	case reflect.Float64:
+		v.SetFloat(randomFloat(rand, math.MaxFloat64))
+	case reflect.Complex64:
+		v.SetComplex(complex(randomFloat(rand, math.MaxFloat32), randomFloat(rand, math.MaxFloat32)))
+	case reflect.Complex128:
+		v.SetComplex(complex(randomFloat(rand, math.MaxFloat64), randomFloat(rand, math.MaxFloat64)))
+	case reflect.String:
+		runes := make([]rune, rand.Intn(size+1))
+		for i := range runes {
+			runes[i] = rune(rand.Intn(0x10ffff))
+		}
+		v.SetString(string(runes))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := randomValue(t.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Index(i).Set(elem)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				return reflect.Value{}, false
+			}
+			elem, ok := randomValue(t.Field(i).Type, rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Field(i).Set(elem)
+		}
+	default:
+		return reflect.Value{}, false
+	}
+	return v, true
+}
+
+// randomFloat returns a random float in (-max, max).
+func randomFloat(rand *rand.Rand, max float64) float64 {
+	f := rand.Float64() * max
+	if rand.Intn(2) == 0 {
+		f = -f
+	}
+	return f
+}
@@ -0,0 +1,105 @@
+package null_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+var _ quick.Generator = null.T[int]{}
+
+func TestGenerate_Check(t *testing.T) {
+	// Properties of T are checked with quick.Check.
+	roundTrip := func(x null.T[int64]) bool {
+		b, err := json.Marshal(x)
+		if err != nil {
+			return false
+		}
+		var y null.T[int64]
+		return json.Unmarshal(b, &y) == nil && x == y
+	}
+	requireNoError(t, quick.Check(roundTrip, nil))
+
+	equal := func(x, y null.T[time.Time]) bool {
+		return x.Equal(y) == y.Equal(x)
+	}
+	requireNoError(t, quick.Check(equal, nil))
+}
+
+func TestGenerate_Distribution(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 10000
+	nulls := 0
+	for i := 0; i < n; i++ {
+		v, ok := quick.Value(reflect.TypeOf(null.T[string]{}), r)
+		if !ok {
+			t.Fatal("quick.Value failed")
+		}
+		if v.Interface().(null.T[string]).IsNull() {
+			nulls++
+		}
+	}
+	if nulls < n/5 || nulls > n*3/10 {
+		t.Errorf("got %d nulls out of %d, want about %d", nulls, n, n/4)
+	}
+}
+
+type pair struct{ X, Y int8 }
+
+type generated int
+
+func (generated) Generate(*rand.Rand, int) reflect.Value { return reflect.ValueOf(generated(7)) }
+
+func TestGenerate_Payloads(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	generate := func(x interface {
+		Generate(*rand.Rand, int) reflect.Value
+	}) any {
+		for {
+			v := x.Generate(r, 10).Interface()
+			if !v.(interface{ IsNull() bool }).IsNull() {
+				return v
+			}
+		}
+	}
+
+	tm := generate(null.T[time.Time]{}).(null.T[time.Time]).ValueOrZero()
+	assertEqual(t, tm.Location() == time.UTC, true)
+	assertEqual(t, tm.Year() >= 1970 && tm.Year() < 2100, true)
+
+	_ = generate(null.T[pair]{}).(null.T[pair])
+	_ = generate(null.T[[3]bool]{}).(null.T[[3]bool])
+	_ = generate(null.T[complex64]{}).(null.T[complex64])
+	assertEqual(t, generate(null.T[generated]{}).(null.T[generated]), null.From[generated](7))
+
+	s := generate(null.T[string]{}).(null.T[string]).ValueOrZero()
+	assertEqual(t, len([]rune(s)) <= 10, true)
+}
+
+func TestGenerate_Unsupported(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, x := range []interface {
+		Generate(*rand.Rand, int) reflect.Value
+	}{
+		null.T[*int]{},
+		null.T[any]{},
+		null.T[struct{ x int }]{},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%T: want panic", x)
+				}
+			}()
+			// Nulls are generated without a payload, so try until the payload is.
+			for i := 0; i < 100; i++ {
+				x.Generate(r, 10)
+			}
+		}()
+	}
+}
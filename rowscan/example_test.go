@@ -0,0 +1,55 @@
+package rowscan_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/internal/fakedb"
+	"github.com/qawatake/null/rowscan"
+)
+
+func ExampleScanAll() {
+	type Book struct {
+		ID     int64
+		Title  string
+		Rating null.T[float64] `db:"avg_rating"`
+	}
+
+	db := fakedb.Open(
+		[]string{"id", "title", "avg_rating"},
+		[]driver.Value{int64(1), "The Go Programming Language", 4.5},
+		[]driver.Value{int64(2), "Unreleased", nil},
+	)
+	rows, err := db.Query("SELECT id, title, avg_rating FROM books")
+	if err != nil {
+		panic(err)
+	}
+	books, err := rowscan.ScanAll[Book](rows)
+	if err != nil {
+		panic(err)
+	}
+	for _, b := range books {
+		fmt.Println(b.ID, b.Title, b.Rating.Ptr() != nil)
+	}
+	// Output:
+	// 1 The Go Programming Language true
+	// 2 Unreleased false
+}
+
+func ExampleNullError() {
+	type Book struct {
+		ID    int64
+		Title string
+	}
+
+	db := fakedb.Open([]string{"id", "title"}, []driver.Value{int64(1), nil})
+	rows, err := db.Query("SELECT id, title FROM books")
+	if err != nil {
+		panic(err)
+	}
+	_, err = rowscan.ScanAll[Book](rows)
+	fmt.Println(err)
+	// Output:
+	// sql: Scan error on column index 1, name "title": rowscan: column "title" is NULL but field Book.Title of type string is not nullable; use null.T[string] instead
+}
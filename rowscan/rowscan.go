@@ -0,0 +1,222 @@
+// Package rowscan scans rows of database/sql into structs.
+//
+// Columns are mapped to exported struct fields by the db tag or, without a tag,
+// by the field name, both compared case-insensitively.
+// Fields of embedded structs are promoted as in Go, and a field tagged db:"-" is ignored.
+//
+// Fields whose pointer implements sql.Scanner, such as null.T, and fields of pointer types
+// receive NULL as usual. Scanning NULL into any other field fails with a *NullError
+// that names the column and the field.
+package rowscan
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	sql1_22 "github.com/qawatake/null/internal/sql"
+)
+
+// NullError is returned when a column is NULL but the field it is scanned into cannot hold NULL.
+type NullError struct {
+	Column string
+	Field  string
+	Type   reflect.Type
+}
+
+// Error implements the error interface.
+func (e *NullError) Error() string {
+	return fmt.Sprintf("rowscan: column %q is NULL but field %s of type %v is not nullable; use null.T[%v] instead", e.Column, e.Field, e.Type, e.Type)
+}
+
+// ScanRow scans the current row of rows into dst, which must be a non-nil pointer to a struct.
+// Every column must map to a field.
+func ScanRow(rows *sql.Rows, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("rowscan: destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+	p, err := planOf(v.Elem().Type())
+	if err != nil {
+		return err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	b, err := p.bind(columns)
+	if err != nil {
+		return err
+	}
+	return b.scan(rows, v.Elem())
+}
+
+// ScanAll scans all the remaining rows into a slice of S, which must be a struct type, and closes rows.
+// Every column must map to a field.
+func ScanAll[S any](rows *sql.Rows) ([]S, error) {
+	defer rows.Close()
+	p, err := planOf(reflect.TypeOf((*S)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.bind(columns)
+	if err != nil {
+		return nil, err
+	}
+	var ss []S
+	for rows.Next() {
+		var s S
+		if err := b.scan(rows, reflect.ValueOf(&s).Elem()); err != nil {
+			return nil, err
+		}
+		ss = append(ss, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ss, rows.Close()
+}
+
+// plans caches a *plan per struct type.
+var plans sync.Map
+
+// plan holds the fields of a struct type which columns can be scanned into.
+type plan struct {
+	typ    reflect.Type
+	fields map[string]field
+}
+
+type field struct {
+	name     string // qualified name for errors, such as User.Address.City
+	index    []int
+	nullable bool
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+func planOf(t reflect.Type) (*plan, error) {
+	if p, ok := plans.Load(t); ok {
+		return p.(*plan), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rowscan: %v is not a struct type", t)
+	}
+	p := &plan{typ: t, fields: make(map[string]field)}
+	candidates := make(map[string][]candidate)
+	p.collect(t, t.Name(), nil, 0, candidates)
+	for key, cs := range candidates {
+		// A field at a shallower depth hides fields of the same name at deeper ones,
+		// and two fields of the same name at the shallowest depth are ambiguous.
+		best := cs[0]
+		ambiguous := false
+		for _, c := range cs[1:] {
+			switch {
+			case c.depth < best.depth:
+				best, ambiguous = c, false
+			case c.depth == best.depth:
+				ambiguous = true
+			}
+		}
+		if ambiguous {
+			return nil, fmt.Errorf("rowscan: ambiguous column %q in %v", best.column, t)
+		}
+		p.fields[key] = best.field
+	}
+	v, _ := plans.LoadOrStore(t, p)
+	return v.(*plan), nil
+}
+
+// candidate is a field of a plan before the fields of the same name are resolved.
+type candidate struct {
+	field
+	column string
+	depth  int
+}
+
+// collect adds the fields of t to candidates, keyed by their lower-cased column names.
+func (p *plan) collect(t reflect.Type, prefix string, index []int, depth int, candidates map[string][]candidate) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		idx := append(index[:len(index):len(index)], i)
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct && !reflect.PointerTo(f.Type).Implements(scannerType) {
+			p.collect(f.Type, prefix, idx, depth+1, candidates)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = f.Name
+		}
+		key := strings.ToLower(name)
+		candidates[key] = append(candidates[key], candidate{
+			field: field{
+				name:     prefix + "." + f.Name,
+				index:    idx,
+				nullable: f.Type.Kind() == reflect.Pointer || reflect.PointerTo(f.Type).Implements(scannerType),
+			},
+			column: name,
+			depth:  depth,
+		})
+	}
+}
+
+// binding is a plan bound to the columns of rows.
+type binding struct {
+	columns []string
+	fields  []field
+}
+
+func (p *plan) bind(columns []string) (*binding, error) {
+	b := &binding{columns: columns, fields: make([]field, len(columns))}
+	for i, c := range columns {
+		f, ok := p.fields[strings.ToLower(c)]
+		if !ok {
+			return nil, fmt.Errorf("rowscan: no field for column %q in %v", c, p.typ)
+		}
+		b.fields[i] = f
+	}
+	return b, nil
+}
+
+func (b *binding) scan(rows *sql.Rows, v reflect.Value) error {
+	dests := make([]any, len(b.fields))
+	for i, f := range b.fields {
+		fv := v.FieldByIndex(f.index)
+		if f.nullable {
+			dests[i] = fv.Addr().Interface()
+		} else {
+			dests[i] = &notNull{dest: fv.Addr().Interface(), column: b.columns[i], field: f.name, typ: fv.Type()}
+		}
+	}
+	return rows.Scan(dests...)
+}
+
+// notNull scans a value into dest and reports a *NullError for NULL.
+type notNull struct {
+	dest   any
+	column string
+	field  string
+	typ    reflect.Type
+}
+
+var _ sql.Scanner = (*notNull)(nil)
+
+// Scan implements the sql.Scanner interface.
+func (n *notNull) Scan(src any) error {
+	if src == nil {
+		return &NullError{Column: n.column, Field: n.field, Type: n.typ}
+	}
+	return sql1_22.ConvertAssign(n.dest, src)
+}
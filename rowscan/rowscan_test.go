@@ -0,0 +1,193 @@
+package rowscan_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/internal/fakedb"
+	"github.com/qawatake/null/rowscan"
+)
+
+type Base struct {
+	ID        int64
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type User struct {
+	Base
+	Name     string
+	Email    null.T[string] `db:"email_address"`
+	Age      null.T[int]
+	Nickname *string
+	Score    float64
+	Secret   string `db:"-"`
+	internal int
+}
+
+func TestScanAll(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := fakedb.Open(
+		[]string{"id", "created_at", "name", "EMAIL_ADDRESS", "age", "nickname", "score"},
+		[]driver.Value{int64(1), now, []byte("alice"), "alice@example.com", int64(20), "ally", "1.5"},
+		[]driver.Value{int64(2), now, "bob", nil, nil, nil, float64(2)},
+	)
+	rows, err := db.Query("SELECT")
+	requireNoError(t, err)
+
+	got, err := rowscan.ScanAll[User](rows)
+	requireNoError(t, err)
+	want := []User{
+		{
+			Base:     Base{ID: 1, CreatedAt: now},
+			Name:     "alice",
+			Email:    null.From("alice@example.com"),
+			Age:      null.From(20),
+			Nickname: toptr("ally"),
+			Score:    1.5,
+		},
+		{
+			Base:  Base{ID: 2, CreatedAt: now},
+			Name:  "bob",
+			Score: 2,
+		},
+	}
+	assertEqual(t, got, want)
+}
+
+func TestScanRow(t *testing.T) {
+	db := fakedb.Open([]string{"id", "name"}, []driver.Value{int64(7), "carol"})
+	rows, err := db.Query("SELECT")
+	requireNoError(t, err)
+	defer rows.Close()
+
+	var u User
+	assertEqual(t, rows.Next(), true)
+	requireNoError(t, rowscan.ScanRow(rows, &u))
+	assertEqual(t, u, User{Base: Base{ID: 7}, Name: "carol"})
+
+	requireError(t, rowscan.ScanRow(rows, u))
+	requireError(t, rowscan.ScanRow(rows, (*User)(nil)))
+	requireError(t, rowscan.ScanRow(rows, new(int)))
+}
+
+func TestScanAll_NullError(t *testing.T) {
+	db := fakedb.Open([]string{"id", "created_at"}, []driver.Value{int64(1), nil})
+	rows, err := db.Query("SELECT")
+	requireNoError(t, err)
+
+	_, err = rowscan.ScanAll[User](rows)
+	var nullErr *rowscan.NullError
+	if !errors.As(err, &nullErr) {
+		t.Fatalf("want *rowscan.NullError, got %v", err)
+	}
+	assertEqual(t, nullErr.Column, "created_at")
+	assertEqual(t, nullErr.Field, "User.CreatedAt")
+	assertEqual(t, nullErr.Type == reflect.TypeOf(time.Time{}), true)
+}
+
+func TestScanAll_Error(t *testing.T) {
+	type Ambiguous struct {
+		A string `db:"name"`
+		B string `db:"NAME"`
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		row     []driver.Value
+	}{
+		{name: "unknown column", columns: []string{"unknown"}, row: []driver.Value{int64(1)}},
+		{name: "ignored column", columns: []string{"secret"}, row: []driver.Value{"s"}},
+		{name: "unexported column", columns: []string{"internal"}, row: []driver.Value{int64(1)}},
+		{name: "conversion", columns: []string{"id"}, row: []driver.Value{"abc"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := fakedb.Open(tt.columns, tt.row).Query("SELECT")
+			requireNoError(t, err)
+			_, err = rowscan.ScanAll[User](rows)
+			requireError(t, err)
+		})
+	}
+
+	t.Run("ambiguous", func(t *testing.T) {
+		rows, err := fakedb.Open([]string{"name"}, []driver.Value{"a"}).Query("SELECT")
+		requireNoError(t, err)
+		_, err = rowscan.ScanAll[Ambiguous](rows)
+		requireError(t, err)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		rows, err := fakedb.Open([]string{"id"}, []driver.Value{int64(1)}).Query("SELECT")
+		requireNoError(t, err)
+		_, err = rowscan.ScanAll[int](rows)
+		requireError(t, err)
+	})
+}
+
+func TestScanAll_Shadowing(t *testing.T) {
+	type Inner struct {
+		Name string
+		Kind string
+	}
+	type Outer struct {
+		Inner
+		Name string
+	}
+
+	rows, err := fakedb.Open([]string{"name", "kind"}, []driver.Value{"outer", "k"}).Query("SELECT")
+	requireNoError(t, err)
+	got, err := rowscan.ScanAll[Outer](rows)
+	requireNoError(t, err)
+	assertEqual(t, got, []Outer{{Inner: Inner{Kind: "k"}, Name: "outer"}})
+
+	t.Run("hides ambiguous embedded fields declared first", func(t *testing.T) {
+		type A struct{ X int }
+		type B struct{ X int }
+		type S struct {
+			A
+			B
+			X int
+		}
+
+		rows, err := fakedb.Open([]string{"x"}, []driver.Value{int64(1)}).Query("SELECT")
+		requireNoError(t, err)
+		got, err := rowscan.ScanAll[S](rows)
+		requireNoError(t, err)
+		assertEqual(t, got, []S{{X: 1}})
+	})
+}
+
+func toptr[T any](v T) *T {
+	return &v
+}
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y, cmp.AllowUnexported(User{})); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
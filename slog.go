@@ -0,0 +1,85 @@
+package null
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+var _ slog.LogValuer = T[int]{}
+
+// LogValue implements the slog.LogValuer interface.
+// A null T is logged as nil, which the JSON handler writes as null.
+// Otherwise, the payload is logged as is, so the LogValue method of the payload, if any, is used.
+func (t T[V]) LogValue() slog.Value {
+	if t.IsNull() {
+		return slog.AnyValue(nil)
+	}
+	return slog.AnyValue(t.v.V)
+}
+
+// Sensitive is a T whose payload is never logged by log/slog.
+// It behaves as T except for LogValue, which reports only whether it is null,
+// so personal data scanned into a Sensitive does not reach the logs:
+//
+//	var email null.Sensitive[string]
+//	row.Scan(&email)
+//	slog.Info("user", "email", email) // email=[set]
+//
+// Its Format and MarshalJSON methods redact the payload as well, so it is not printed by the fmt package
+// or encoded as JSON, even as a field of a struct logged by the JSON handler of slog,
+// and the errors of Scan and UnmarshalJSON do not quote the input.
+// Use s.T to encode the payload deliberately.
+type Sensitive[V comparable] struct {
+	T[V]
+}
+
+var _ slog.LogValuer = Sensitive[int]{}
+
+// redacted is logged in place of the payload of a non-null Sensitive.
+const redacted = "[set]"
+
+// LogValue implements the slog.LogValuer interface.
+// It returns "[set]" if s is not null and nil otherwise.
+func (s Sensitive[V]) LogValue() slog.Value {
+	if s.IsNull() {
+		return slog.AnyValue(nil)
+	}
+	return slog.StringValue(redacted)
+}
+
+var _ json.Marshaler = Sensitive[int]{}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It writes "[set]" if s is not null and null otherwise.
+func (s Sensitive[V]) MarshalJSON() ([]byte, error) {
+	if s.IsNull() {
+		return nullBytes, nil
+	}
+	return json.Marshal(redacted)
+}
+
+var _ sql.Scanner = &Sensitive[int]{}
+
+// Scan implements the sql.Scanner interface.
+// It scans src as T does, but the error, if any, reports only the types,
+// since that of T may quote src.
+func (s *Sensitive[V]) Scan(src any) error {
+	if err := s.T.Scan(src); err != nil {
+		return fmt.Errorf("null: cannot scan %T into %T", src, s)
+	}
+	return nil
+}
+
+var _ json.Unmarshaler = &Sensitive[int]{}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It decodes data as T does, but the error, if any, reports only the type,
+// since that of T may quote data.
+func (s *Sensitive[V]) UnmarshalJSON(data []byte) error {
+	if err := s.T.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("null: cannot unmarshal JSON into %T", s)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package null_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qawatake/null"
+)
+
+type logValuer struct{ secret string }
+
+func (logValuer) LogValue() slog.Value { return slog.StringValue("***") }
+
+func TestLogValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		wantJSON string
+		wantText string
+	}{
+		{name: "null", value: null.T[int]{}, wantJSON: `{"k":null}`, wantText: `k=<nil>`},
+		{name: "int", value: null.From(3), wantJSON: `{"k":3}`, wantText: `k=3`},
+		{name: "string", value: null.From("a b"), wantJSON: `{"k":"a b"}`, wantText: `k="a b"`},
+		{name: "zero", value: null.From(""), wantJSON: `{"k":""}`, wantText: `k=""`},
+		{name: "time", value: null.From(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), wantJSON: `{"k":"2024-01-02T03:04:05Z"}`, wantText: `k=2024-01-02T03:04:05.000Z`},
+		{name: "payload LogValuer", value: null.From(logValuer{secret: "x"}), wantJSON: `{"k":"***"}`, wantText: `k=***`},
+		{name: "sensitive null", value: null.Sensitive[string]{}, wantJSON: `{"k":null}`, wantText: `k=<nil>`},
+		{name: "sensitive", value: null.Sensitive[string]{T: null.From("alice@example.com")}, wantJSON: `{"k":"[set]"}`, wantText: `k=[set]`},
+		{name: "sensitive zero", value: null.Sensitive[string]{T: null.From("")}, wantJSON: `{"k":"[set]"}`, wantText: `k=[set]`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, logLine(slog.NewJSONHandler, tt.value), tt.wantJSON)
+			assertEqual(t, logLine(slog.NewTextHandler, tt.value), tt.wantText)
+		})
+	}
+}
+
+func TestLogValue_Group(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropBuiltins})).Info("",
+		slog.Group("user", "name", null.From("bob"), "email", null.Sensitive[string]{T: null.From("bob@example.com")}, "phone", null.T[string]{}),
+	)
+	assertEqual(t, string(bytes.TrimSpace(buf.Bytes())), `{"user":{"name":"bob","email":"[set]","phone":null}}`)
+}
+
+func TestSensitive(t *testing.T) {
+	// Sensitive behaves as T except for logging.
+	var s null.Sensitive[string]
+	requireNoError(t, s.Scan("x"))
+	assertEqual(t, s.T, null.From("x"))
+	v, err := s.Value()
+	requireNoError(t, err)
+	assertEqual(t, v, any("x"))
+}
+
+func TestSensitive_JSON(t *testing.T) {
+	type user struct {
+		Name  null.T[string]
+		Email null.Sensitive[string]
+		Phone null.Sensitive[string]
+	}
+	b, err := json.Marshal(user{Name: null.From("alice"), Email: null.Sensitive[string]{T: null.From("alice@example.com")}})
+	requireNoError(t, err)
+	assertEqual(t, string(b), `{"Name":"alice","Email":"[set]","Phone":null}`)
+
+	var s null.Sensitive[string]
+	requireNoError(t, json.Unmarshal([]byte(`"bob@example.com"`), &s))
+	assertEqual(t, s.T, null.From("bob@example.com"))
+
+	// A struct logged as a whole by the JSON handler is redacted as well.
+	line := logLine(slog.NewJSONHandler, user{Email: null.Sensitive[string]{T: null.From("bob@example.com")}})
+	assertEqual(t, strings.Contains(line, "bob@example.com"), false)
+}
+
+func TestSensitive_Errors(t *testing.T) {
+	// The errors of T quote the input, which those of Sensitive must not.
+	var n null.Sensitive[int]
+	err := n.Scan("4111-1111-1111-1111")
+	requireError(t, err)
+	assertEqual(t, strings.Contains(err.Error(), "4111"), false)
+	assertEqual(t, n.IsNull(), true)
+
+	err = json.Unmarshal([]byte(`4111.1111`), &n)
+	requireError(t, err)
+	assertEqual(t, strings.Contains(err.Error(), "4111"), false)
+
+	var tn null.T[int]
+	err = tn.Scan("4111-1111-1111-1111")
+	requireError(t, err)
+	assertEqual(t, strings.Contains(err.Error(), "4111"), true)
+}
+
+func logLine[H slog.Handler](newHandler func(w io.Writer, opts *slog.HandlerOptions) H, v any) string {
+	var buf bytes.Buffer
+	slog.New(newHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropBuiltins})).Info("", "k", v)
+	return string(bytes.TrimSpace(buf.Bytes()))
+}
+
+// dropBuiltins removes the time, level and message from log lines.
+func dropBuiltins(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+		return slog.Attr{}
+	}
+	return a
+}
@@ -0,0 +1,31 @@
+package sqlpred_test
+
+import (
+	"fmt"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/sqlpred"
+)
+
+func ExampleBuilder_Eq() {
+	var manager null.T[int64]
+	team := null.From("platform")
+
+	b := sqlpred.New(sqlpred.Postgres)
+	fmt.Println(b.Eq("manager_id", manager) + " AND " + b.Eq("team", team))
+	args, _ := b.Args()
+	fmt.Println(len(args))
+	// Output:
+	// manager_id IS NULL AND team = $1
+	// 1
+}
+
+func ExampleBuilder_NotEq() {
+	team := null.From("platform")
+
+	fmt.Println(sqlpred.New(sqlpred.Postgres).NotEq("team", team))
+	fmt.Println(sqlpred.New(sqlpred.MySQL).NotEq("team", team))
+	// Output:
+	// team IS DISTINCT FROM $1
+	// (team <> ? OR team IS NULL)
+}
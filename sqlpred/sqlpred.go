@@ -0,0 +1,187 @@
+// Package sqlpred builds NULL-safe SQL predicates from nullable values such as null.T.
+//
+// A null parameter never matches in col = ?, because NULL = NULL is unknown in SQL.
+// A Builder renders col IS NULL for null values and col = ? otherwise,
+// and collects the arguments in the placeholder style of the dialect.
+//
+//	b := sqlpred.New(sqlpred.Postgres)
+//	where := b.Eq("email", email) + " AND " + b.NotEq("status", status)
+//	args, err := b.Args()
+//	if err != nil {
+//		return err
+//	}
+//	rows, err := db.Query("SELECT * FROM users WHERE "+where, args...)
+//
+// Column names are written as they are given; quote them beforehand if necessary.
+package sqlpred
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Placeholder is a style of query parameters.
+type Placeholder int
+
+const (
+	// Question renders ?, as in MySQL and SQLite.
+	Question Placeholder = iota
+	// Dollar renders $1, $2, ..., as in PostgreSQL.
+	Dollar
+	// AtP renders @p1, @p2, ..., as in SQL Server.
+	AtP
+	// Colon renders :name with an argument of sql.Named, as in Oracle.
+	// The name is derived from the column.
+	Colon
+)
+
+// Quote is a style of quoted identifiers.
+type Quote int
+
+const (
+	// DoubleQuote renders "name", as in standard SQL.
+	DoubleQuote Quote = iota
+	// Backquote renders `name`, as in MySQL.
+	Backquote
+	// Bracket renders [name], as in SQL Server.
+	Bracket
+)
+
+// Dialect describes how a database writes predicates.
+type Dialect struct {
+	Placeholder Placeholder
+	Quote       Quote
+	// DistinctFrom reports whether the database supports IS DISTINCT FROM.
+	DistinctFrom bool
+}
+
+// Dialects of well-known databases.
+var (
+	Postgres  = Dialect{Placeholder: Dollar, DistinctFrom: true}
+	MySQL     = Dialect{Placeholder: Question, Quote: Backquote}
+	SQLite    = Dialect{Placeholder: Question, DistinctFrom: true}
+	SQLServer = Dialect{Placeholder: AtP, Quote: Bracket}
+	Oracle    = Dialect{Placeholder: Colon}
+)
+
+// QuoteIdent quotes an identifier, escaping the quote characters in it.
+func (d Dialect) QuoteIdent(name string) string {
+	switch d.Quote {
+	case Backquote:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case Bracket:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+// Builder renders predicates and collects their arguments.
+// The zero value renders with ? placeholders, double-quoted identifiers and without IS DISTINCT FROM.
+type Builder struct {
+	dialect Dialect
+	args    []any
+	names   map[string]bool
+	err     error
+}
+
+// New returns a Builder for the dialect.
+func New(d Dialect) *Builder {
+	return &Builder{dialect: d}
+}
+
+// Dialect returns the dialect of b.
+func (b *Builder) Dialect() Dialect {
+	return b.dialect
+}
+
+// Args returns the arguments of the predicates rendered so far, in the order of their placeholders.
+// It returns the first error of the Value methods called while rendering, if any.
+func (b *Builder) Args() ([]any, error) {
+	return b.args, b.err
+}
+
+// Eq renders a predicate that holds if col equals v, treating NULL as equal to NULL:
+// col IS NULL if v is null and col = ? otherwise.
+// v is null if its Value method returns nil.
+func (b *Builder) Eq(col string, v driver.Valuer) string {
+	if b.isNull(v) {
+		return col + " IS NULL"
+	}
+	return col + " = " + b.Param(col, v)
+}
+
+// NotEq renders the negation of Eq:
+// col IS NOT NULL if v is null and col IS DISTINCT FROM ? otherwise.
+// For a dialect without IS DISTINCT FROM, it renders (col <> ? OR col IS NULL) instead.
+func (b *Builder) NotEq(col string, v driver.Valuer) string {
+	if b.isNull(v) {
+		return col + " IS NOT NULL"
+	}
+	p := b.Param(col, v)
+	if b.dialect.DistinctFrom {
+		return col + " IS DISTINCT FROM " + p
+	}
+	return "(" + col + " <> " + p + " OR " + col + " IS NULL)"
+}
+
+// isNull reports whether v is null and records the error of its Value method.
+func (b *Builder) isNull(v driver.Valuer) bool {
+	if v == nil {
+		return true
+	}
+	dv, err := v.Value()
+	if err != nil && b.err == nil {
+		b.err = fmt.Errorf("sqlpred: %w", err)
+	}
+	return dv == nil
+}
+
+// Param adds v to the arguments and returns its placeholder.
+// For the Colon style, the name of the parameter is derived from col.
+func (b *Builder) Param(col string, v any) string {
+	switch b.dialect.Placeholder {
+	case Dollar:
+		b.args = append(b.args, v)
+		return "$" + strconv.Itoa(len(b.args))
+	case AtP:
+		b.args = append(b.args, v)
+		return "@p" + strconv.Itoa(len(b.args))
+	case Colon:
+		name := b.name(col)
+		b.args = append(b.args, sql.Named(name, v))
+		return ":" + name
+	default:
+		b.args = append(b.args, v)
+		return "?"
+	}
+}
+
+// name returns a parameter name derived from col which is not used yet.
+func (b *Builder) name(col string) string {
+	// Use the last part of a qualified name and drop characters not allowed in identifiers.
+	if i := strings.LastIndexByte(col, '.'); i >= 0 {
+		col = col[i+1:]
+	}
+	base := strings.Map(func(r rune) rune {
+		if r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return -1
+	}, col)
+	if base == "" || '0' <= base[0] && base[0] <= '9' {
+		base = "p" + base
+	}
+	if b.names == nil {
+		b.names = make(map[string]bool)
+	}
+	name := base
+	for i := 2; b.names[name]; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	b.names[name] = true
+	return name
+}
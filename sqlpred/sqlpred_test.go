@@ -0,0 +1,155 @@
+package sqlpred_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/internal/fakedb"
+	"github.com/qawatake/null/sqlpred"
+)
+
+func TestBuilder(t *testing.T) {
+	email := null.From("a@example.com")
+	var deleted null.T[int64]
+	status := sql.NullString{String: "active", Valid: true}
+
+	tests := []struct {
+		name     string
+		dialect  sqlpred.Dialect
+		want     string
+		wantArgs []driver.NamedValue
+	}{
+		{
+			name:    "Postgres",
+			dialect: sqlpred.Postgres,
+			want:    "SELECT 1 WHERE email = $1 AND deleted_at IS NULL AND status IS DISTINCT FROM $2 AND deleted_at IS NOT NULL AND u.email = $3",
+			wantArgs: []driver.NamedValue{
+				{Ordinal: 1, Value: "a@example.com"},
+				{Ordinal: 2, Value: "active"},
+				{Ordinal: 3, Value: "a@example.com"},
+			},
+		},
+		{
+			name:    "MySQL",
+			dialect: sqlpred.MySQL,
+			want:    "SELECT 1 WHERE email = ? AND deleted_at IS NULL AND (status <> ? OR status IS NULL) AND deleted_at IS NOT NULL AND u.email = ?",
+			wantArgs: []driver.NamedValue{
+				{Ordinal: 1, Value: "a@example.com"},
+				{Ordinal: 2, Value: "active"},
+				{Ordinal: 3, Value: "a@example.com"},
+			},
+		},
+		{
+			name:    "SQLite",
+			dialect: sqlpred.SQLite,
+			want:    "SELECT 1 WHERE email = ? AND deleted_at IS NULL AND status IS DISTINCT FROM ? AND deleted_at IS NOT NULL AND u.email = ?",
+			wantArgs: []driver.NamedValue{
+				{Ordinal: 1, Value: "a@example.com"},
+				{Ordinal: 2, Value: "active"},
+				{Ordinal: 3, Value: "a@example.com"},
+			},
+		},
+		{
+			name:    "SQLServer",
+			dialect: sqlpred.SQLServer,
+			want:    "SELECT 1 WHERE email = @p1 AND deleted_at IS NULL AND (status <> @p2 OR status IS NULL) AND deleted_at IS NOT NULL AND u.email = @p3",
+			wantArgs: []driver.NamedValue{
+				{Ordinal: 1, Value: "a@example.com"},
+				{Ordinal: 2, Value: "active"},
+				{Ordinal: 3, Value: "a@example.com"},
+			},
+		},
+		{
+			name:    "Oracle",
+			dialect: sqlpred.Oracle,
+			want:    "SELECT 1 WHERE email = :email AND deleted_at IS NULL AND (status <> :status OR status IS NULL) AND deleted_at IS NOT NULL AND u.email = :email_2",
+			wantArgs: []driver.NamedValue{
+				{Name: "email", Ordinal: 1, Value: "a@example.com"},
+				{Name: "status", Ordinal: 2, Value: "active"},
+				{Name: "email_2", Ordinal: 3, Value: "a@example.com"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			b := sqlpred.New(tt.dialect)
+			where := b.Eq("email", email) +
+				" AND " + b.Eq("deleted_at", deleted) +
+				" AND " + b.NotEq("status", status) +
+				" AND " + b.NotEq("deleted_at", deleted) +
+				" AND " + b.Eq("u.email", email)
+			args, err := b.Args()
+			requireNoError(t, err)
+
+			var db fakedb.DB
+			rows, err := db.Open().Query("SELECT 1 WHERE "+where, args...)
+			requireNoError(t, err)
+			requireNoError(t, rows.Close())
+			assertEqual(t, db.Query(), tt.want)
+			assertEqual(t, db.Args(), tt.wantArgs)
+		})
+	}
+}
+
+func TestBuilder_ZeroValue(t *testing.T) {
+	var b sqlpred.Builder
+	assertEqual(t, b.Eq("a", null.From(1)), "a = ?")
+	assertEqual(t, b.Eq("b", nil), "b IS NULL")
+	args, err := b.Args()
+	requireNoError(t, err)
+	assertEqual(t, len(args), 1)
+}
+
+func TestBuilder_Name(t *testing.T) {
+	b := sqlpred.New(sqlpred.Oracle)
+	assertEqual(t, b.Eq(`"Order Date"`, null.From(1)), `"Order Date" = :OrderDate`)
+	assertEqual(t, b.Eq("1st", null.From(1)), "1st = :p1st")
+	assertEqual(t, b.Eq("p1st", null.From(1)), "p1st = :p1st_2")
+	assertEqual(t, b.Eq("#", null.From(1)), "# = :p")
+}
+
+func TestDialect_QuoteIdent(t *testing.T) {
+	assertEqual(t, sqlpred.Postgres.QuoteIdent(`a"b`), `"a""b"`)
+	assertEqual(t, sqlpred.MySQL.QuoteIdent("a`b"), "`a``b`")
+	assertEqual(t, sqlpred.SQLServer.QuoteIdent("a]b"), "[a]]b]")
+}
+
+type failingValuer struct{}
+
+func (failingValuer) Value() (driver.Value, error) {
+	return nil, errFailure
+}
+
+var errFailure = errors.New("failure")
+
+func TestBuilder_Error(t *testing.T) {
+	b := sqlpred.New(sqlpred.Postgres)
+	_ = b.Eq("a", failingValuer{})
+	_ = b.Eq("b", null.From(1))
+	_, err := b.Args()
+	if !errors.Is(err, errFailure) {
+		t.Errorf("want %v, got %v", errFailure, err)
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package sqlupdate_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/qawatake/null/sqlpred"
+	"github.com/qawatake/null/sqlupdate"
+)
+
+func ExampleSet() {
+	type UserPatch struct {
+		Name     sqlupdate.Field[string] `json:"name" db:"name"`
+		Email    sqlupdate.Field[string] `json:"email" db:"email"`
+		Nickname sqlupdate.Field[string] `json:"nickname" db:"nickname"`
+	}
+
+	var p UserPatch
+	if err := json.Unmarshal([]byte(`{"name":"alice","nickname":null}`), &p); err != nil {
+		panic(err)
+	}
+
+	b := sqlpred.New(sqlpred.Postgres)
+	set, err := sqlupdate.Set(b, p)
+	if err != nil {
+		panic(err)
+	}
+	query := "UPDATE users " + set + " WHERE id = " + b.Param("id", 42)
+	args, err := b.Args()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(query)
+	fmt.Println(len(args))
+	// Output:
+	// UPDATE users SET "name" = $1, "nickname" = NULL WHERE id = $2
+	// 2
+}
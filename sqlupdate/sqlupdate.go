@@ -0,0 +1,231 @@
+// Package sqlupdate renders the SET clause of partial UPDATE statements from structs of nullable fields.
+//
+// Each exported field is a column named by its db tag or, without a tag, by the field name.
+// Fields of embedded structs are promoted, and a field tagged db:"-" is ignored.
+// A column is rendered as col = NULL if the field is null and as col = ? otherwise,
+// where a field is null if it is a nil pointer or its Value method returns nil, as for null.T.
+//
+// Absent fields are skipped. A field is absent if
+//
+//   - it is a Field which is not present, or
+//   - the struct has a field of type Mask which does not contain its column.
+//
+// Columns are rendered in the order of the fields and quoted for the dialect of the sqlpred.Builder,
+// which also numbers the placeholders, so that a WHERE clause can follow:
+//
+//	b := sqlpred.New(sqlpred.Postgres)
+//	set, err := sqlupdate.Set(b, patch)
+//	if err != nil {
+//		return err
+//	}
+//	query := "UPDATE users " + set + " WHERE id = " + b.Param("id", id)
+//	args, err := b.Args()
+package sqlupdate
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/sqlpred"
+)
+
+// ErrEmpty is returned when no column is to be updated.
+var ErrEmpty = errors.New("sqlupdate: no columns to update")
+
+// Mask is the set of columns to update.
+// If a struct has an exported field of type Mask, columns not in it are skipped.
+// The Mask field itself is not a column.
+type Mask map[string]bool
+
+// Field is a tri-state value: absent, null or a value.
+// The zero value is absent.
+//
+// When decoded from JSON, a Field is present if its key appears in the object,
+// and null if the value is null.
+type Field[V comparable] struct {
+	t       null.T[V]
+	present bool
+}
+
+// Present returns a present Field holding t.
+func Present[V comparable](t null.T[V]) Field[V] {
+	return Field[V]{t: t, present: true}
+}
+
+// IsPresent reports whether f is present.
+func (f Field[V]) IsPresent() bool {
+	return f.present
+}
+
+// T returns the value of f. It is null if f is absent.
+func (f Field[V]) T() null.T[V] {
+	return f.t
+}
+
+// IsZero reports whether f is absent.
+// With the omitzero option of encoding/json, absent fields are omitted.
+func (f Field[V]) IsZero() bool {
+	return !f.present
+}
+
+var (
+	_ json.Unmarshaler = (*Field[int])(nil)
+	_ json.Marshaler   = Field[int]{}
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *Field[V]) UnmarshalJSON(data []byte) error {
+	if err := f.t.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	f.present = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// An absent Field is encoded as null.
+func (f Field[V]) MarshalJSON() ([]byte, error) {
+	return f.t.MarshalJSON()
+}
+
+func (f Field[V]) presence() (driver.Valuer, bool) {
+	return f.t, f.present
+}
+
+// tristate is implemented by Field.
+type tristate interface {
+	presence() (driver.Valuer, bool)
+}
+
+var (
+	maskType     = reflect.TypeOf(Mask(nil))
+	tristateType = reflect.TypeOf((*tristate)(nil)).Elem()
+)
+
+// Set renders the SET clause for the present fields of v, which must be a struct or a pointer to a struct.
+// It returns ErrEmpty if all fields are absent.
+func Set(b *sqlpred.Builder, v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return "", fmt.Errorf("sqlupdate: %T is not a struct or a pointer to a struct", v)
+	}
+	var fs fields
+	if err := fs.collect(rv); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	d := b.Dialect()
+	for _, c := range fs.cols {
+		val := c.value
+		if t, ok := val.(tristate); ok {
+			var present bool
+			val, present = t.presence()
+			if !present {
+				continue
+			}
+		}
+		if fs.hasMask && !fs.mask[c.name] {
+			continue
+		}
+		if buf.Len() == 0 {
+			buf.WriteString("SET ")
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(c.name))
+		buf.WriteString(" = ")
+		isNull, err := isNull(val)
+		if err != nil {
+			return "", err
+		}
+		if isNull {
+			buf.WriteString("NULL")
+		} else {
+			buf.WriteString(b.Param(c.name, val))
+		}
+	}
+	if buf.Len() == 0 {
+		return "", ErrEmpty
+	}
+	return buf.String(), nil
+}
+
+type column struct {
+	name  string
+	value any
+}
+
+// fields holds the columns of a struct in the order of the fields and its mask.
+type fields struct {
+	cols    []column
+	mask    Mask
+	hasMask bool
+}
+
+func (fs *fields) collect(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := v.Field(i)
+		if f.IsExported() && f.Type == maskType {
+			if fs.hasMask {
+				return fmt.Errorf("sqlupdate: more than one Mask in %v", t)
+			}
+			fs.mask, fs.hasMask = fv.Interface().(Mask), true
+			continue
+		}
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct && !f.Type.Implements(tristateType) && !isValuer(f.Type) {
+			if err := fs.collect(fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = f.Name
+		}
+		fs.cols = append(fs.cols, column{name: name, value: fv.Interface()})
+	}
+	return nil
+}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+func isValuer(t reflect.Type) bool {
+	return t.Implements(valuerType)
+}
+
+// isNull reports whether v is a nil pointer or a driver.Valuer whose value is nil.
+func isNull(v any) (bool, error) {
+	if v == nil {
+		return true, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return true, nil
+	}
+	valuer, ok := v.(driver.Valuer)
+	if !ok {
+		return false, nil
+	}
+	dv, err := valuer.Value()
+	if err != nil {
+		return false, fmt.Errorf("sqlupdate: %w", err)
+	}
+	return dv == nil, nil
+}
@@ -0,0 +1,211 @@
+package sqlupdate_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/qawatake/null"
+	"github.com/qawatake/null/sqlpred"
+	"github.com/qawatake/null/sqlupdate"
+)
+
+type Audit struct {
+	UpdatedBy null.T[string] `db:"updated_by"`
+}
+
+type UserPatch struct {
+	Name     sqlupdate.Field[string] `db:"name"`
+	Email    sqlupdate.Field[string] `db:"email"`
+	Age      sqlupdate.Field[int]    `db:"age"`
+	Nickname *string                 `db:"nick name"`
+	Score    sql.NullFloat64         `db:"score"`
+	Version  int
+	Internal string `db:"-"`
+	Audit
+	secret string
+}
+
+func TestSet(t *testing.T) {
+	p := UserPatch{
+		Name:    sqlupdate.Present(null.From("alice")),
+		Email:   sqlupdate.Present(null.T[string]{}),
+		Score:   sql.NullFloat64{Float64: 1.5, Valid: true},
+		Version: 3,
+		Audit:   Audit{UpdatedBy: null.From("admin")},
+		secret:  "x",
+	}
+
+	tests := []struct {
+		name     string
+		dialect  sqlpred.Dialect
+		want     string
+		wantArgs []any
+	}{
+		{
+			name:     "Postgres",
+			dialect:  sqlpred.Postgres,
+			want:     `SET "name" = $1, "email" = NULL, "nick name" = NULL, "score" = $2, "Version" = $3, "updated_by" = $4`,
+			wantArgs: []any{null.From("alice"), p.Score, 3, null.From("admin")},
+		},
+		{
+			name:     "MySQL",
+			dialect:  sqlpred.MySQL,
+			want:     "SET `name` = ?, `email` = NULL, `nick name` = NULL, `score` = ?, `Version` = ?, `updated_by` = ?",
+			wantArgs: []any{null.From("alice"), p.Score, 3, null.From("admin")},
+		},
+		{
+			name:     "SQLServer",
+			dialect:  sqlpred.SQLServer,
+			want:     "SET [name] = @p1, [email] = NULL, [nick name] = NULL, [score] = @p2, [Version] = @p3, [updated_by] = @p4",
+			wantArgs: []any{null.From("alice"), p.Score, 3, null.From("admin")},
+		},
+		{
+			name:     "Oracle",
+			dialect:  sqlpred.Oracle,
+			want:     `SET "name" = :name, "email" = NULL, "nick name" = NULL, "score" = :score, "Version" = :Version, "updated_by" = :updated_by`,
+			wantArgs: []any{sql.Named("name", null.From("alice")), sql.Named("score", p.Score), sql.Named("Version", 3), sql.Named("updated_by", null.From("admin"))},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			b := sqlpred.New(tt.dialect)
+			got, err := sqlupdate.Set(b, &p)
+			requireNoError(t, err)
+			assertEqual(t, got, tt.want)
+			args, err := b.Args()
+			requireNoError(t, err)
+			if diff := cmp.Diff(args, tt.wantArgs, cmp.AllowUnexported(sql.NamedArg{})); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestSet_Deterministic(t *testing.T) {
+	p := UserPatch{Name: sqlupdate.Present(null.From("a")), Age: sqlupdate.Present(null.From(1))}
+	want, err := sqlupdate.Set(sqlpred.New(sqlpred.Postgres), p)
+	requireNoError(t, err)
+	for i := 0; i < 100; i++ {
+		got, err := sqlupdate.Set(sqlpred.New(sqlpred.Postgres), p)
+		requireNoError(t, err)
+		assertEqual(t, got, want)
+	}
+}
+
+func TestSet_JSON(t *testing.T) {
+	type Patch struct {
+		Name  sqlupdate.Field[string] `json:"name" db:"name"`
+		Email sqlupdate.Field[string] `json:"email" db:"email"`
+		Age   sqlupdate.Field[int]    `json:"age" db:"age"`
+	}
+
+	var p Patch
+	requireNoError(t, json.Unmarshal([]byte(`{"name":"bob","email":null}`), &p))
+	assertEqual(t, p.Name.IsPresent(), true)
+	assertEqual(t, p.Name.T(), null.From("bob"))
+	assertEqual(t, p.Email.IsPresent(), true)
+	assertEqual(t, p.Email.T().IsNull(), true)
+	assertEqual(t, p.Age.IsPresent(), false)
+
+	b := sqlpred.New(sqlpred.Postgres)
+	got, err := sqlupdate.Set(b, p)
+	requireNoError(t, err)
+	assertEqual(t, got, `SET "name" = $1, "email" = NULL`)
+
+	requireError(t, json.Unmarshal([]byte(`{"age":"x"}`), &p))
+
+	data, err := json.Marshal(Patch{Name: sqlupdate.Present(null.From("bob"))})
+	requireNoError(t, err)
+	assertEqual(t, string(data), `{"name":"bob","email":null,"age":null}`)
+}
+
+func TestSet_Mask(t *testing.T) {
+	type Patch struct {
+		Name  null.T[string] `db:"name"`
+		Email null.T[string] `db:"email"`
+		Age   null.T[int]    `db:"age"`
+		Mask  sqlupdate.Mask
+	}
+
+	p := Patch{Name: null.From("carol"), Mask: sqlupdate.Mask{"name": true, "email": true}}
+	b := sqlpred.New(sqlpred.Postgres)
+	got, err := sqlupdate.Set(b, p)
+	requireNoError(t, err)
+	assertEqual(t, got, `SET "name" = $1, "email" = NULL`)
+
+	// Without a mask, all columns are updated.
+	p.Mask = nil
+	got, err = sqlupdate.Set(sqlpred.New(sqlpred.Postgres), struct {
+		Name null.T[string] `db:"name"`
+		Age  null.T[int]    `db:"age"`
+	}{Name: p.Name})
+	requireNoError(t, err)
+	assertEqual(t, got, `SET "name" = $1, "age" = NULL`)
+
+	// A nil Mask skips all columns.
+	_, err = sqlupdate.Set(sqlpred.New(sqlpred.Postgres), p)
+	if !errors.Is(err, sqlupdate.ErrEmpty) {
+		t.Errorf("want %v, got %v", sqlupdate.ErrEmpty, err)
+	}
+}
+
+type failingValuer struct{}
+
+func (failingValuer) Value() (driver.Value, error) {
+	return nil, errFailure
+}
+
+var errFailure = errors.New("failure")
+
+func TestSet_Error(t *testing.T) {
+	_, err := sqlupdate.Set(sqlpred.New(sqlpred.Postgres), UserPatch{Audit: Audit{}, Nickname: nil})
+	requireNoError(t, err) // Nickname, Score, Version and updated_by are always set.
+
+	_, err = sqlupdate.Set(sqlpred.New(sqlpred.Postgres), struct{ A sqlupdate.Field[int] }{})
+	if !errors.Is(err, sqlupdate.ErrEmpty) {
+		t.Errorf("want %v, got %v", sqlupdate.ErrEmpty, err)
+	}
+
+	_, err = sqlupdate.Set(sqlpred.New(sqlpred.Postgres), struct{ A failingValuer }{})
+	if !errors.Is(err, errFailure) {
+		t.Errorf("want %v, got %v", errFailure, err)
+	}
+
+	_, err = sqlupdate.Set(sqlpred.New(sqlpred.Postgres), struct{ A, B sqlupdate.Mask }{})
+	requireError(t, err)
+
+	_, err = sqlupdate.Set(sqlpred.New(sqlpred.Postgres), 1)
+	requireError(t, err)
+
+	_, err = sqlupdate.Set(sqlpred.New(sqlpred.Postgres), (*UserPatch)(nil))
+	requireError(t, err)
+}
+
+func requireError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("want error, but got nil")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, x T, y T) bool {
+	t.Helper()
+	if diff := cmp.Diff(x, y); diff != "" {
+		t.Errorf(diff)
+		return false
+	}
+	return true
+}